@@ -2,7 +2,7 @@ package api
 
 import "time"
 
-//go:generate mockgen -package mock -destination ../mock/mock_api.go github.com/evcc-io/evcc/api Charger,ChargeState,ChargePhases,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery
+//go:generate mockgen -package mock -destination ../mock/mock_api.go github.com/evcc-io/evcc/api Charger,ChargeState,ChargePhases,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,ChargerDischarge,VehiclePosition,VehicleChargeLimiter,VehicleStartCharge,VehicleStopCharge,VehicleClimateController
 
 // ChargeMode are charge modes modeled after OpenWB
 type ChargeMode string
@@ -83,6 +83,17 @@ type ChargePhases interface {
 	Phases1p3p(phases int) error
 }
 
+// ChargerDischarge provides bidirectional charging, feeding power from the vehicle battery back to the house
+type ChargerDischarge interface {
+	MaxDischargeCurrent(current float64) error // zero current stops discharging
+	DischargePower() (float64, error)
+}
+
+// FaultReporter provides the charger's reason for being in fault status E or F
+type FaultReporter interface {
+	FaultDetail() (string, error)
+}
+
 // Diagnosis is a helper interface that allows to dump diagnostic data to console
 type Diagnosis interface {
 	Diagnose()
@@ -126,11 +137,28 @@ type VehicleClimater interface {
 	Climater() (active bool, outsideTemp float64, targetTemp float64, err error)
 }
 
+// VehicleClimateController starts and stops vehicle preconditioning
+type VehicleClimateController interface {
+	StartClimate() error
+	StopClimate() error
+}
+
 // VehicleOdometer returns the vehicles milage
 type VehicleOdometer interface {
 	Odometer() (float64, error)
 }
 
+// VehicleChargeLimiter reads and sets the vehicles own charge limit
+type VehicleChargeLimiter interface {
+	TargetSoC() (int, error)
+	SetTargetSoC(soc int) error
+}
+
+// VehiclePosition returns the vehicles position in latitude and longitude
+type VehiclePosition interface {
+	Position() (float64, float64, error)
+}
+
 // VehicleStartCharge starts the charging session on the vehicle side
 type VehicleStartCharge interface {
 	StartCharge() error
@@ -141,6 +169,11 @@ type VehicleStopCharge interface {
 	StopCharge() error
 }
 
+// CircuitBreaker provides the state of the vehicle api's request limiter
+type CircuitBreaker interface {
+	CircuitState() string
+}
+
 type Tariff interface {
 	IsCheap() bool
 }
@@ -90,6 +90,7 @@ export default {
       power: "Leistung",
       range: "Reichweite",
       charged: "Geladen",
+      discharged: "Entladen",
       duration: "Dauer",
       remaining: "Restzeit",
     },
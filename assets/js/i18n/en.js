@@ -90,6 +90,7 @@ export default {
       power: "Power",
       range: "Range",
       charged: "Charged",
+      discharged: "Discharged",
       duration: "Duration",
       remaining: "Remaining",
     },
@@ -70,6 +70,7 @@ export default {
       power: "Potenza",
       range: "Autonomia",
       charged: "Ricaricato",
+      discharged: "Scaricato",
       duration: "Duarata",
       remaining: "Rimanenti",
     },
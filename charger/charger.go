@@ -20,9 +20,15 @@ func init() {
 	registry.Add(api.Custom, NewConfigurableFromConfig)
 }
 
+// go:generate go run ../cmd/tools/decorate.go -f decorateCustom -b *Charger -r api.Charger -t "api.ChargerEx,MaxCurrentMillis,func(current float64) error"
+
 // NewConfigurableFromConfig creates a new configurable charger
 func NewConfigurableFromConfig(other map[string]interface{}) (api.Charger, error) {
-	cc := struct{ Status, Enable, Enabled, MaxCurrent provider.Config }{}
+	cc := struct {
+		Status, Enable, Enabled, MaxCurrent provider.Config
+		MaxCurrentMillis                    *provider.Config // optional
+	}{}
+
 	if err := util.DecodeOther(other, &cc); err != nil {
 		return nil, err
 	}
@@ -47,7 +53,21 @@ func NewConfigurableFromConfig(other map[string]interface{}) (api.Charger, error
 		return nil, fmt.Errorf("maxcurrent: %w", err)
 	}
 
-	return NewConfigurable(status, enabled, enable, maxcurrent)
+	c, err := NewConfigurable(status, enabled, enable, maxcurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	// decorate Charger with ChargerEx
+	var maxCurrentMillis func(float64) error
+	if cc.MaxCurrentMillis != nil {
+		maxCurrentMillis, err = provider.NewFloatSetterFromConfig("maxcurrentmillis", *cc.MaxCurrentMillis)
+		if err != nil {
+			return nil, fmt.Errorf("maxcurrentmillis: %w", err)
+		}
+	}
+
+	return decorateCustom(c, maxCurrentMillis), nil
 }
 
 // NewConfigurable creates a new charger
@@ -56,7 +76,7 @@ func NewConfigurable(
 	enabledG func() (bool, error),
 	enableS func(bool) error,
 	maxCurrentS func(int64) error,
-) (api.Charger, error) {
+) (*Charger, error) {
 	c := &Charger{
 		statusG:     statusG,
 		enabledG:    enabledG,
@@ -0,0 +1,35 @@
+package charger
+
+// Code generated by github.com/andig/cmd/tools/decorate.go. DO NOT EDIT.
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+func decorateCustom(base *Charger, chargerEx func(current float64) error) api.Charger {
+	switch {
+	case chargerEx == nil:
+		return base
+
+	case chargerEx != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+		}
+	}
+
+	return nil
+}
+
+type decorateCustomChargerExImpl struct {
+	chargerEx func(current float64) error
+}
+
+func (impl *decorateCustomChargerExImpl) MaxCurrentMillis(current float64) error {
+	return impl.chargerEx(current)
+}
@@ -26,6 +26,15 @@ func (r chargerRegistry) Get(name string) (func(map[string]interface{}) (api.Cha
 
 var registry chargerRegistry = make(map[string]func(map[string]interface{}) (api.Charger, error))
 
+// Types returns the list of charger types
+func Types() []string {
+	var res []string
+	for typ := range registry {
+		res = append(res, typ)
+	}
+	return res
+}
+
 // NewFromConfig creates charger from configuration
 func NewFromConfig(typ string, other map[string]interface{}) (v api.Charger, err error) {
 	factory, err := registry.Get(strings.ToLower(typ))
@@ -19,7 +19,9 @@ package charger
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -37,9 +39,11 @@ type Easee struct {
 	*request.Helper
 	charger       string
 	site, circuit int
+	mux           sync.Mutex
 	status        easee.ChargerStatus
 	updated       time.Time
 	cache         time.Duration
+	stream        *easee.Stream
 	lp            loadpoint.API
 	//lastSmartCharging bool
 	//lastChargeMode api.ChargeMode
@@ -60,19 +64,21 @@ func NewEaseeFromConfig(other map[string]interface{}) (api.Charger, error) {
 		Charger  string
 		Circuit  int
 		Cache    time.Duration
+		Stream   bool
 	}{
-		Cache: 10 * time.Second,
+		Cache:  10 * time.Second,
+		Stream: true,
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
 		return nil, err
 	}
 
-	return NewEasee(cc.User, cc.Password, cc.Charger, cc.Circuit, cc.Cache)
+	return NewEasee(cc.User, cc.Password, cc.Charger, cc.Circuit, cc.Cache, cc.Stream)
 }
 
 // NewEasee creates Easee charger
-func NewEasee(user, password, charger string, circuit int, cache time.Duration) (*Easee, error) {
+func NewEasee(user, password, charger string, circuit int, cache time.Duration, stream bool) (*Easee, error) {
 	log := util.NewLogger("easee")
 
 	if !sponsor.IsAuthorized() {
@@ -130,9 +136,50 @@ func NewEasee(user, password, charger string, circuit int, cache time.Duration)
 		c.circuit = site.Circuits[0].ID
 	}
 
+	// receive real-time updates, polling is used while the stream is disconnected
+	if stream {
+		c.stream = easee.NewStream(log, easee.StreamAPI, ts, c.charger, c.observe)
+		go c.stream.Run()
+	}
+
 	return c, err
 }
 
+// observe applies streamed observations to the charger state
+func (c *Easee) observe(o easee.Observation) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	var err error
+
+	switch o.ID {
+	case easee.ChargerOpMode:
+		c.status.ChargerOpMode, err = o.Int()
+	case easee.TotalPower:
+		c.status.TotalPower, err = o.Float()
+	case easee.SessionEnergy:
+		c.status.SessionEnergy, err = o.Float()
+	case easee.LifetimeEnergy:
+		c.status.LifetimeEnergy, err = o.Float()
+	case easee.CircuitTotalPhaseConductorCurrentL1:
+		c.status.CircuitTotalPhaseConductorCurrentL1, err = o.Float()
+	case easee.CircuitTotalPhaseConductorCurrentL2:
+		c.status.CircuitTotalPhaseConductorCurrentL2, err = o.Float()
+	case easee.CircuitTotalPhaseConductorCurrentL3:
+		c.status.CircuitTotalPhaseConductorCurrentL3, err = o.Float()
+	case easee.PhaseMode:
+		// phase mode 1 and 3 are fixed, 2 is automatic
+		var mode int
+		if mode, err = o.Int(); err == nil && (mode == 1 || mode == 3) {
+			c.phases = mode
+		}
+	}
+
+	if err != nil {
+		c.log.ERROR.Printf("observation %d: %v", o.ID, err)
+	}
+}
+
 func (c *Easee) chargers() (res []easee.Charger, err error) {
 	uri := fmt.Sprintf("%s/chargers", easee.API)
 
@@ -201,22 +248,44 @@ func (c *Easee) syncSmartCharging() error {
 */
 
 func (c *Easee) state() (easee.ChargerStatus, error) {
-	if time.Since(c.updated) < c.cache {
+	c.mux.Lock()
+
+	// streamed state is current once initialized
+	if (c.stream != nil && c.stream.Connected() && !c.updated.IsZero()) || time.Since(c.updated) < c.cache {
+		defer c.mux.Unlock()
 		return c.status, nil
 	}
 
+	c.mux.Unlock()
+
+	// poll without blocking stream observations
+	var res easee.ChargerStatus
+
 	uri := fmt.Sprintf("%s/chargers/%s/state", easee.API, c.charger)
 	req, err := request.New(http.MethodGet, uri, nil, request.JSONEncoding)
 	if err == nil {
-		if err = c.DoJSON(req, &c.status); err == nil {
-			// err = c.syncSmartCharging()
-			c.updated = time.Now()
-		}
+		err = c.DoJSON(req, &res)
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err == nil {
+		// err = c.syncSmartCharging()
+		c.status = res
+		c.updated = time.Now()
 	}
 
 	return c.status, err
 }
 
+// clearCache forces the next state update to poll the api
+func (c *Easee) clearCache() {
+	c.mux.Lock()
+	c.updated = time.Time{}
+	c.mux.Unlock()
+}
+
 // Status implements the api.Charger interface
 func (c *Easee) Status() (api.ChargeStatus, error) {
 	res, err := c.state()
@@ -263,7 +332,7 @@ func (c *Easee) Enable(enable bool) error {
 			resp.Body.Close()
 		}
 
-		c.updated = time.Time{} // clear cache
+		c.clearCache()
 
 		return err
 	}
@@ -276,7 +345,7 @@ func (c *Easee) Enable(enable bool) error {
 
 	uri := fmt.Sprintf("%s/chargers/%s/commands/%s", easee.API, c.charger, action)
 	_, err = c.Post(uri, request.JSONContent, nil)
-	c.updated = time.Time{} // clear cache
+	c.clearCache()
 
 	return err
 }
@@ -290,8 +359,12 @@ var _ api.ChargerEx = (*Easee)(nil)
 
 // MaxCurrentMillis implements the api.ChargerEx interface
 func (c *Easee) MaxCurrentMillis(current float64) error {
+	c.mux.Lock()
+	phases := c.phases
+	c.mux.Unlock()
+
 	var current23 float64
-	if c.phases > 1 {
+	if phases > 1 {
 		current23 = current
 	}
 
@@ -306,8 +379,10 @@ func (c *Easee) MaxCurrentMillis(current float64) error {
 	if err == nil {
 		resp.Body.Close()
 
+		c.mux.Lock()
 		c.updated = time.Time{} // clear cache
 		c.current = current
+		c.mux.Unlock()
 	}
 
 	return err
@@ -317,8 +392,12 @@ var _ api.ChargePhases = (*Easee)(nil)
 
 // Phases1p3p implements the api.ChargePhases interface
 func (c *Easee) Phases1p3p(phases int) error {
+	c.mux.Lock()
 	c.phases = phases
-	return c.MaxCurrentMillis(c.current)
+	current := c.current
+	c.mux.Unlock()
+
+	return c.MaxCurrentMillis(current)
 }
 
 var _ api.Meter = (*Easee)(nil)
@@ -348,6 +427,16 @@ func (c *Easee) Currents() (float64, float64, float64, error) {
 		err
 }
 
+var _ io.Closer = (*Easee)(nil)
+
+// Close implements the io.Closer interface and terminates the stream
+func (c *Easee) Close() error {
+	if c.stream != nil {
+		c.stream.Close()
+	}
+	return nil
+}
+
 var _ loadpoint.Controller = (*Easee)(nil)
 
 // LoadpointControl implements loadpoint.Controller
@@ -1,5 +1,10 @@
 package easee
 
+import (
+	"strconv"
+	"time"
+)
+
 // API is the Easee API endpoint
 const API = "https://api.easee.cloud/api"
 
@@ -147,3 +152,54 @@ type CircuitSettings struct {
 	OfflineMaxCircuitCurrentP2 *int     `json:"offlineMaxCircuitCurrentP2,omitempty"`
 	OfflineMaxCircuitCurrentP3 *int     `json:"offlineMaxCircuitCurrentP3,omitempty"`
 }
+
+// ObservationID identifies a charger observation of the streaming api
+type ObservationID int
+
+// observation ids
+const (
+	PhaseMode      ObservationID = 38
+	ChargerOpMode  ObservationID = 109
+	TotalPower     ObservationID = 120
+	SessionEnergy  ObservationID = 121
+	LifetimeEnergy ObservationID = 124
+
+	CircuitTotalPhaseConductorCurrentL1 ObservationID = 48
+	CircuitTotalPhaseConductorCurrentL2 ObservationID = 49
+	CircuitTotalPhaseConductorCurrentL3 ObservationID = 50
+)
+
+// observation data types
+const (
+	Binary     int = 1
+	Boolean    int = 2
+	Double     int = 3
+	Integer    int = 4
+	Position   int = 5
+	String     int = 6
+	Statistics int = 7
+)
+
+// Observation is a single charger value received from the streaming api
+type Observation struct {
+	Mid       string        `json:"mid"`
+	DataType  int           `json:"dataType"`
+	ID        ObservationID `json:"id"`
+	Timestamp time.Time     `json:"timestamp"`
+	Value     string        `json:"value"`
+}
+
+// Float returns the observation's numeric value
+func (o Observation) Float() (float64, error) {
+	return strconv.ParseFloat(o.Value, 64)
+}
+
+// Int returns the observation's integer value
+func (o Observation) Int() (int, error) {
+	return strconv.Atoi(o.Value)
+}
+
+// Bool returns the observation's boolean value
+func (o Observation) Bool() (bool, error) {
+	return strconv.ParseBool(o.Value)
+}
@@ -0,0 +1,327 @@
+package easee
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/gorilla/websocket"
+	"golang.org/x/oauth2"
+)
+
+// StreamAPI is the Easee observation stream (SignalR hub) endpoint
+const StreamAPI = "https://streams.easee.com/hubs/chargers"
+
+// signalR message types
+const (
+	signalRInvocation = 1
+	signalRPing       = 6
+	signalRClose      = 7
+
+	signalRSeparator = 0x1e
+)
+
+const (
+	streamKeepAlive = 15 * time.Second
+	streamTimeout   = 2 * streamKeepAlive
+	streamMaxDelay  = 5 * time.Minute
+)
+
+type signalRMessage struct {
+	Type      int               `json:"type"`
+	Target    string            `json:"target,omitempty"`
+	Arguments []json.RawMessage `json:"arguments,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// Stream is a SignalR client receiving charger observations from the Easee streaming hub.
+// The stream reconnects with increasing delay until closed.
+type Stream struct {
+	*request.Helper
+	log       *util.Logger
+	uri       string
+	charger   string
+	ts        oauth2.TokenSource
+	handler   func(Observation)
+	mu        sync.Mutex
+	conn      *websocket.Conn
+	connected bool
+	done      chan struct{}
+}
+
+// NewStream creates a stream client for the given charger. Observations are passed to handler.
+func NewStream(log *util.Logger, uri string, ts oauth2.TokenSource, charger string, handler func(Observation)) *Stream {
+	s := &Stream{
+		Helper:  request.NewHelper(log),
+		log:     log,
+		uri:     strings.TrimSuffix(uri, "/"),
+		charger: charger,
+		ts:      ts,
+		handler: handler,
+		done:    make(chan struct{}),
+	}
+
+	s.Client.Transport = &oauth2.Transport{
+		Source: ts,
+		Base:   s.Client.Transport,
+	}
+
+	return s
+}
+
+// Connected returns true if the stream is receiving observations
+func (s *Stream) Connected() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connected
+}
+
+// Run connects the stream and keeps it connected until Close is called
+func (s *Stream) Run() {
+	delay := time.Second
+
+	for {
+		started := time.Now()
+		err := s.run()
+
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+
+		// reset delay after stable connection
+		if time.Since(started) > streamMaxDelay {
+			delay = time.Second
+		}
+
+		s.log.ERROR.Printf("stream: %v, reconnecting in %v", err, delay)
+
+		select {
+		case <-s.done:
+			return
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > streamMaxDelay {
+			delay = streamMaxDelay
+		}
+	}
+}
+
+// Close terminates the stream
+func (s *Stream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
+
+// negotiate retrieves the connection token
+func (s *Stream) negotiate() (string, error) {
+	var res struct {
+		ConnectionID    string `json:"connectionId"`
+		ConnectionToken string `json:"connectionToken"`
+	}
+
+	uri := fmt.Sprintf("%s/negotiate?negotiateVersion=1", s.uri)
+	req, err := request.New(http.MethodPost, uri, nil, request.JSONEncoding)
+	if err == nil {
+		err = s.DoJSON(req, &res)
+	}
+
+	if res.ConnectionToken == "" {
+		res.ConnectionToken = res.ConnectionID
+	}
+
+	return res.ConnectionToken, err
+}
+
+// dial opens the websocket connection and performs the SignalR handshake
+func (s *Stream) dial() (*websocket.Conn, error) {
+	id, err := s.negotiate()
+	if err != nil {
+		return nil, fmt.Errorf("negotiate: %w", err)
+	}
+
+	token, err := s.ts.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(s.uri)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Scheme = strings.Replace(u.Scheme, "http", "ws", 1)
+	u.RawQuery = url.Values{
+		"id":           []string{id},
+		"access_token": []string{token.AccessToken},
+	}.Encode()
+
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.send(conn, map[string]interface{}{"protocol": "json", "version": 1}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(streamTimeout))
+	_, b, err := conn.ReadMessage()
+	if err == nil {
+		var res signalRMessage
+		if err = json.Unmarshal(bytes.TrimSuffix(b, []byte{signalRSeparator}), &res); err == nil && res.Error != "" {
+			err = errors.New(res.Error)
+		}
+	}
+
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("handshake: %w", err)
+	}
+
+	return conn, nil
+}
+
+// send writes a single SignalR message
+func (s *Stream) send(conn *websocket.Conn, msg interface{}) error {
+	b, err := json.Marshal(msg)
+	if err == nil {
+		err = conn.WriteMessage(websocket.TextMessage, append(b, signalRSeparator))
+	}
+	return err
+}
+
+// run connects, subscribes and receives observations until the connection fails
+func (s *Stream) run() error {
+	conn, err := s.dial()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		conn.Close()
+		return errors.New("closed")
+	default:
+	}
+
+	var wmu sync.Mutex
+	send := func(msg interface{}) error {
+		wmu.Lock()
+		defer wmu.Unlock()
+		return s.send(conn, msg)
+	}
+
+	defer func() {
+		s.mu.Lock()
+		s.connected = false
+		s.conn = nil
+		s.mu.Unlock()
+
+		conn.Close()
+	}()
+
+	if err := send(signalRMessage{
+		Type:      signalRInvocation,
+		Target:    "SubscribeWithCurrentState",
+		Arguments: []json.RawMessage{json.RawMessage(fmt.Sprintf("%q", s.charger)), json.RawMessage("true")},
+	}); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.connected = true
+	s.mu.Unlock()
+
+	s.log.DEBUG.Println("stream: connected")
+
+	// keep alive
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(streamKeepAlive):
+				if err := send(signalRMessage{Type: signalRPing}); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(streamTimeout))
+
+		_, b, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		for _, frame := range bytes.Split(b, []byte{signalRSeparator}) {
+			if len(frame) == 0 {
+				continue
+			}
+
+			var msg signalRMessage
+			if err := json.Unmarshal(frame, &msg); err != nil {
+				s.log.ERROR.Printf("stream: %v", err)
+				continue
+			}
+
+			switch msg.Type {
+			case signalRInvocation:
+				s.invocation(msg)
+			case signalRClose:
+				if msg.Error != "" {
+					return errors.New(msg.Error)
+				}
+				return errors.New("closed by server")
+			}
+		}
+	}
+}
+
+// invocation handles server to client invocations
+func (s *Stream) invocation(msg signalRMessage) {
+	if msg.Target != "ProductUpdate" {
+		return
+	}
+
+	for _, arg := range msg.Arguments {
+		var o Observation
+		if err := json.Unmarshal(arg, &o); err != nil {
+			s.log.ERROR.Printf("stream: %v", err)
+			continue
+		}
+
+		s.handler(o)
+	}
+}
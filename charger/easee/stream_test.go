@@ -0,0 +1,125 @@
+package easee
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/gorilla/websocket"
+	"golang.org/x/oauth2"
+)
+
+// fakeHub is a minimal SignalR hub sending observations after subscription
+func fakeHub(t *testing.T, charger string, observations []Observation) *httptest.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/hub/negotiate", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"negotiateVersion":1,"connectionId":"id","connectionToken":"ctoken"}`))
+	})
+
+	upgrader := websocket.Upgrader{}
+
+	mux.HandleFunc("/hub", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "ctoken" || r.URL.Query().Get("access_token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+
+		read := func() (res signalRMessage) {
+			_, b, err := conn.ReadMessage()
+			if err == nil {
+				err = json.Unmarshal(bytes.TrimSuffix(b, []byte{signalRSeparator}), &res)
+			}
+			if err != nil {
+				t.Error(err)
+			}
+			return res
+		}
+
+		// handshake
+		read()
+		_ = conn.WriteMessage(websocket.TextMessage, []byte("{}\x1e"))
+
+		// subscription
+		msg := read()
+		if msg.Target != "SubscribeWithCurrentState" || len(msg.Arguments) != 2 || string(msg.Arguments[0]) != `"`+charger+`"` {
+			t.Errorf("unexpected subscription: %+v", msg)
+		}
+
+		var b []byte
+		for _, o := range observations {
+			arg, _ := json.Marshal(o)
+			frame, _ := json.Marshal(signalRMessage{
+				Type:      signalRInvocation,
+				Target:    "ProductUpdate",
+				Arguments: []json.RawMessage{arg},
+			})
+			b = append(append(b, frame...), signalRSeparator)
+		}
+		_ = conn.WriteMessage(websocket.TextMessage, b)
+
+		// wait for client to close
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestStream(t *testing.T) {
+	observations := []Observation{
+		{ID: ChargerOpMode, DataType: Integer, Value: "3"},
+		{ID: TotalPower, DataType: Double, Value: "7.2"},
+	}
+
+	srv := fakeHub(t, "EH123456", observations)
+	defer srv.Close()
+
+	recv := make(chan Observation, len(observations))
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+
+	s := NewStream(util.NewLogger("foo"), srv.URL+"/hub", ts, "EH123456", func(o Observation) {
+		recv <- o
+	})
+
+	go s.Run()
+	defer s.Close()
+
+	for _, expected := range observations {
+		select {
+		case o := <-recv:
+			if o.ID != expected.ID || o.Value != expected.Value {
+				t.Errorf("unexpected observation: %+v", o)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	if !s.Connected() {
+		t.Error("stream not connected")
+	}
+
+	if f, err := observations[1].Float(); err != nil || f != 7.2 {
+		t.Errorf("unexpected value: %v %v", f, err)
+	}
+}
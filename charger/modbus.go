@@ -0,0 +1,412 @@
+package charger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/modbus"
+	"github.com/volkszaehler/mbmd/meters/rs485"
+)
+
+// modbusRegister is a register definition with optional scale factor
+type modbusRegister struct {
+	modbus.Register `mapstructure:",squash"`
+	Scale           float64
+}
+
+// decode returns the register encoding, defaulting to uint16
+func (r modbusRegister) decode() string {
+	if r.Decode == "" {
+		return "uint16"
+	}
+	return r.Decode
+}
+
+// operation creates the modbus operation for the register
+func (r modbusRegister) operation() (rs485.Operation, error) {
+	r.Decode = r.decode()
+	return modbus.RegisterOperation(r.Register)
+}
+
+// scale returns the register scale factor, defaulting to 1
+func (r modbusRegister) scale() float64 {
+	if r.Scale == 0 {
+		return 1
+	}
+	return r.Scale
+}
+
+// modbusReading is a register read operation and its scale factor
+type modbusReading struct {
+	op    rs485.Operation
+	scale float64
+}
+
+// reading creates the scaled read operation for the register
+func (r modbusRegister) reading() (modbusReading, error) {
+	op, err := r.operation()
+	return modbusReading{op: op, scale: r.scale()}, err
+}
+
+// modbusWriting is a register write operation and its encoding
+type modbusWriting struct {
+	op     rs485.Operation
+	decode string
+}
+
+// writing creates the write operation for the register
+func (r modbusRegister) writing() (modbusWriting, error) {
+	op, err := r.operation()
+	if err == nil && op.FuncCode == modbus.WriteSingleRegister && op.ReadLen != 1 {
+		err = fmt.Errorf("invalid register decoding for single register write: %s", r.decode())
+	}
+	return modbusWriting{op: op, decode: r.decode()}, err
+}
+
+// Modbus is an api.Charger implementation for generic modbus wallboxes.
+// Registers are declared in the configuration.
+type Modbus struct {
+	conn          *modbus.Connection
+	status        rs485.Operation
+	statusMap     map[int64]string
+	enabled       *rs485.Operation // separate enabled state register
+	enable        modbusWriting
+	enableValues  map[bool]uint16
+	maxCurrent    modbusWriting
+	maxCurrentS   float64
+	phases        modbusWriting
+	phasesMap     map[int]uint16
+	power, energy modbusReading
+	currents      []modbusReading
+}
+
+func init() {
+	registry.Add("modbus", NewModbusFromConfig)
+}
+
+// go:generate go run ../cmd/tools/decorate.go -f decorateModbus -b *Modbus -r api.Charger -t "api.Meter,CurrentPower,func() (float64, error)" -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.MeterCurrent,Currents,func() (float64, float64, float64, error)" -t "api.ChargerEx,MaxCurrentMillis,func(current float64) error" -t "api.ChargePhases,Phases1p3p,func(phases int) error"
+
+// NewModbusFromConfig creates a generic modbus charger from generic config
+func NewModbusFromConfig(other map[string]interface{}) (api.Charger, error) {
+	cc := struct {
+		modbus.Settings `mapstructure:",squash"`
+		Timeout         time.Duration
+		Delay           time.Duration
+		Status          struct {
+			modbusRegister `mapstructure:",squash"`
+			Map            map[int64]string
+		}
+		Enabled *modbusRegister // required if the enable register is write-only
+		Enable  struct {
+			modbusRegister `mapstructure:",squash"`
+			On, Off        *uint16
+		}
+		MaxCurrent modbusRegister
+		Phases     *struct {
+			modbusRegister `mapstructure:",squash"`
+			Map            map[int]uint16 // register value per phase count, defaults to phase count
+		}
+		Power, Energy *modbusRegister
+		Currents      []modbusRegister
+	}{
+		Settings: modbus.Settings{
+			ID: 1,
+		},
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	format := modbus.TcpFormat
+	if cc.RTU != nil && *cc.RTU {
+		format = modbus.RtuFormat
+	}
+
+	conn, err := modbus.NewConnection(cc.URI, cc.Device, cc.Comset, cc.Baudrate, format, cc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// set non-default timeout
+	if cc.Timeout > 0 {
+		conn.Timeout(cc.Timeout)
+	}
+
+	if cc.Delay > 0 {
+		conn.Delay(cc.Delay)
+	}
+
+	log := util.NewLogger("modbus")
+	conn.Logger(log.TRACE)
+
+	wb := &Modbus{
+		conn:         conn,
+		statusMap:    cc.Status.Map,
+		enableValues: map[bool]uint16{false: 0, true: 1},
+		maxCurrentS:  cc.MaxCurrent.scale(),
+	}
+
+	if wb.status, err = cc.Status.operation(); err != nil {
+		return nil, fmt.Errorf("status: %w", err)
+	}
+
+	if wb.enable, err = cc.Enable.writing(); err != nil {
+		return nil, fmt.Errorf("enable: %w", err)
+	}
+
+	if cc.Enable.On != nil {
+		wb.enableValues[true] = *cc.Enable.On
+	}
+
+	if cc.Enable.Off != nil {
+		wb.enableValues[false] = *cc.Enable.Off
+	}
+
+	// read enabled state from enable register unless configured otherwise
+	if cc.Enabled != nil {
+		op, err := cc.Enabled.operation()
+		if err == nil && !readable(op) {
+			err = fmt.Errorf("invalid read function code %d", op.FuncCode)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("enabled: %w", err)
+		}
+
+		wb.enabled = &op
+	} else if !readable(wb.enable.op) {
+		return nil, errors.New("enabled: required for write-only enable register")
+	}
+
+	if wb.maxCurrent, err = cc.MaxCurrent.writing(); err != nil {
+		return nil, fmt.Errorf("maxcurrent: %w", err)
+	}
+
+	var currentPower func() (float64, error)
+	if cc.Power != nil {
+		if wb.power, err = cc.Power.reading(); err != nil {
+			return nil, fmt.Errorf("power: %w", err)
+		}
+
+		currentPower = wb.currentPower
+	}
+
+	var totalEnergy func() (float64, error)
+	if cc.Energy != nil {
+		if wb.energy, err = cc.Energy.reading(); err != nil {
+			return nil, fmt.Errorf("energy: %w", err)
+		}
+
+		totalEnergy = wb.totalEnergy
+	}
+
+	var currents func() (float64, float64, float64, error)
+	if len(cc.Currents) > 0 {
+		if len(cc.Currents) != 3 {
+			return nil, errors.New("need 3 currents")
+		}
+
+		for idx, reg := range cc.Currents {
+			r, err := reg.reading()
+			if err != nil {
+				return nil, fmt.Errorf("currents[%d]: %w", idx, err)
+			}
+
+			wb.currents = append(wb.currents, r)
+		}
+
+		currents = wb.allCurrents
+	}
+
+	// fractional current setting requires scaled register
+	var maxCurrentMillis func(float64) error
+	if wb.maxCurrentS > 1 {
+		maxCurrentMillis = wb.maxCurrentMillis
+	}
+
+	var phases func(int) error
+	if cc.Phases != nil {
+		if wb.phases, err = cc.Phases.writing(); err != nil {
+			return nil, fmt.Errorf("phases: %w", err)
+		}
+
+		wb.phasesMap = map[int]uint16{1: 1, 3: 3}
+		for phases, val := range cc.Phases.Map {
+			if phases != 1 && phases != 3 {
+				return nil, fmt.Errorf("phases: invalid map key %d", phases)
+			}
+
+			wb.phasesMap[phases] = val
+		}
+
+		phases = wb.phases1p3p
+	}
+
+	return decorateModbus(wb, currentPower, totalEnergy, currents, maxCurrentMillis, phases), nil
+}
+
+// readable checks if the operation's register can be read
+func readable(op rs485.Operation) bool {
+	switch op.FuncCode {
+	case rs485.ReadHoldingReg, rs485.ReadInputReg, modbus.ReadCoils:
+		return true
+	default:
+		return false
+	}
+}
+
+// read executes a modbus read operation
+func (wb *Modbus) read(op rs485.Operation) (float64, error) {
+	var b []byte
+	var err error
+
+	switch op.FuncCode {
+	case rs485.ReadHoldingReg:
+		b, err = wb.conn.ReadHoldingRegisters(op.OpCode, op.ReadLen)
+	case rs485.ReadInputReg:
+		b, err = wb.conn.ReadInputRegisters(op.OpCode, op.ReadLen)
+	case modbus.ReadCoils:
+		b, err = wb.conn.ReadCoils(op.OpCode, 1)
+	default:
+		err = fmt.Errorf("invalid read function code %d", op.FuncCode)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return op.Transform(b), nil
+}
+
+// write executes a modbus register or coil write operation using the register's encoding
+func (wb *Modbus) write(w modbusWriting, val float64) error {
+	var err error
+
+	switch op := w.op; op.FuncCode {
+	case modbus.ReadCoils, modbus.WriteSingleCoil:
+		var u uint16
+		if val != 0 {
+			u = 0xFF00
+		}
+		_, err = wb.conn.WriteSingleCoil(op.OpCode, u)
+
+	case rs485.ReadHoldingReg, modbus.WriteSingleRegister, modbus.WriteMultipleRegisters:
+		var b []byte
+		if b, err = modbus.EncodeRegister(w.decode, val); err != nil {
+			return err
+		}
+
+		// holding registers are written as single register if possible
+		if op.FuncCode == modbus.WriteMultipleRegisters || len(b) > 2 {
+			_, err = wb.conn.WriteMultipleRegisters(op.OpCode, uint16(len(b)/2), b)
+		} else {
+			_, err = wb.conn.WriteSingleRegister(op.OpCode, binary.BigEndian.Uint16(b))
+		}
+
+	default:
+		err = fmt.Errorf("invalid write function code %d", op.FuncCode)
+	}
+
+	return err
+}
+
+// Status implements the api.Charger interface
+func (wb *Modbus) Status() (api.ChargeStatus, error) {
+	f, err := wb.read(wb.status)
+	if err != nil {
+		return api.StatusNone, err
+	}
+
+	u := int64(f)
+	if s, ok := wb.statusMap[u]; ok {
+		return api.ChargeStatus(strings.ToUpper(s)), nil
+	}
+
+	// status register contains ASCII status letter
+	if u >= 'A' && u <= 'F' {
+		return api.ChargeStatus(string(rune(u))), nil
+	}
+
+	return api.StatusNone, fmt.Errorf("invalid status: %d", u)
+}
+
+// Enabled implements the api.Charger interface
+func (wb *Modbus) Enabled() (bool, error) {
+	if wb.enabled != nil {
+		f, err := wb.read(*wb.enabled)
+		return f != 0, err
+	}
+
+	f, err := wb.read(wb.enable.op)
+	if err != nil {
+		return false, err
+	}
+
+	if wb.enable.op.FuncCode == modbus.ReadCoils {
+		return f != 0, nil
+	}
+
+	return uint16(f) == wb.enableValues[true], nil
+}
+
+// Enable implements the api.Charger interface
+func (wb *Modbus) Enable(enable bool) error {
+	return wb.write(wb.enable, float64(wb.enableValues[enable]))
+}
+
+// MaxCurrent implements the api.Charger interface
+func (wb *Modbus) MaxCurrent(current int64) error {
+	return wb.maxCurrentMillis(float64(current))
+}
+
+// maxCurrentMillis implements the api.ChargerEx interface
+func (wb *Modbus) maxCurrentMillis(current float64) error {
+	if current < 6 {
+		return fmt.Errorf("invalid current %.5g", current)
+	}
+
+	return wb.write(wb.maxCurrent, math.Round(current*wb.maxCurrentS))
+}
+
+// phases1p3p implements the api.ChargePhases interface
+func (wb *Modbus) phases1p3p(phases int) error {
+	val, ok := wb.phasesMap[phases]
+	if !ok {
+		return fmt.Errorf("invalid phases: %d", phases)
+	}
+
+	return wb.write(wb.phases, float64(val))
+}
+
+// currentPower implements the api.Meter interface
+func (wb *Modbus) currentPower() (float64, error) {
+	f, err := wb.read(wb.power.op)
+	return wb.power.scale * f, err
+}
+
+// totalEnergy implements the api.MeterEnergy interface
+func (wb *Modbus) totalEnergy() (float64, error) {
+	f, err := wb.read(wb.energy.op)
+	return wb.energy.scale * f, err
+}
+
+// allCurrents implements the api.MeterCurrent interface
+func (wb *Modbus) allCurrents() (float64, float64, float64, error) {
+	var currents []float64
+	for _, r := range wb.currents {
+		f, err := wb.read(r.op)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		currents = append(currents, r.scale*f)
+	}
+
+	return currents[0], currents[1], currents[2], nil
+}
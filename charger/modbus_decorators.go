@@ -0,0 +1,593 @@
+package charger
+
+// Code generated by github.com/andig/cmd/tools/decorate.go. DO NOT EDIT.
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+func decorateModbus(base *Modbus, meter func() (float64, error), meterEnergy func() (float64, error), meterCurrent func() (float64, float64, float64, error), chargerEx func(current float64) error, chargePhases func(phases int) error) api.Charger {
+	switch {
+	case chargePhases == nil && chargerEx == nil && meter == nil && meterCurrent == nil && meterEnergy == nil:
+		return base
+
+	case chargePhases == nil && chargerEx == nil && meter != nil && meterCurrent == nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.Meter
+		}{
+			Modbus: base,
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case chargePhases == nil && chargerEx == nil && meter == nil && meterCurrent == nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases == nil && chargerEx == nil && meter != nil && meterCurrent == nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.Meter
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases == nil && chargerEx == nil && meter == nil && meterCurrent != nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.MeterCurrent
+		}{
+			Modbus: base,
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+
+	case chargePhases == nil && chargerEx == nil && meter != nil && meterCurrent != nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.Meter
+			api.MeterCurrent
+		}{
+			Modbus: base,
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+
+	case chargePhases == nil && chargerEx == nil && meter == nil && meterCurrent != nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.MeterCurrent
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases == nil && chargerEx == nil && meter != nil && meterCurrent != nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.Meter
+			api.MeterCurrent
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases == nil && chargerEx != nil && meter == nil && meterCurrent == nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.ChargerEx
+		}{
+			Modbus: base,
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+		}
+
+	case chargePhases == nil && chargerEx != nil && meter != nil && meterCurrent == nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.ChargerEx
+			api.Meter
+		}{
+			Modbus: base,
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case chargePhases == nil && chargerEx != nil && meter == nil && meterCurrent == nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.ChargerEx
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases == nil && chargerEx != nil && meter != nil && meterCurrent == nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.ChargerEx
+			api.Meter
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases == nil && chargerEx != nil && meter == nil && meterCurrent != nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.ChargerEx
+			api.MeterCurrent
+		}{
+			Modbus: base,
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+
+	case chargePhases == nil && chargerEx != nil && meter != nil && meterCurrent != nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.ChargerEx
+			api.Meter
+			api.MeterCurrent
+		}{
+			Modbus: base,
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+
+	case chargePhases == nil && chargerEx != nil && meter == nil && meterCurrent != nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.ChargerEx
+			api.MeterCurrent
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases == nil && chargerEx != nil && meter != nil && meterCurrent != nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.ChargerEx
+			api.Meter
+			api.MeterCurrent
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases != nil && chargerEx == nil && meter == nil && meterCurrent == nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+		}
+
+	case chargePhases != nil && chargerEx == nil && meter != nil && meterCurrent == nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.Meter
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case chargePhases != nil && chargerEx == nil && meter == nil && meterCurrent == nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases != nil && chargerEx == nil && meter != nil && meterCurrent == nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.Meter
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases != nil && chargerEx == nil && meter == nil && meterCurrent != nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.MeterCurrent
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+
+	case chargePhases != nil && chargerEx == nil && meter != nil && meterCurrent != nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.Meter
+			api.MeterCurrent
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+
+	case chargePhases != nil && chargerEx == nil && meter == nil && meterCurrent != nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.MeterCurrent
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases != nil && chargerEx == nil && meter != nil && meterCurrent != nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.Meter
+			api.MeterCurrent
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases != nil && chargerEx != nil && meter == nil && meterCurrent == nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.ChargerEx
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+		}
+
+	case chargePhases != nil && chargerEx != nil && meter != nil && meterCurrent == nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.ChargerEx
+			api.Meter
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case chargePhases != nil && chargerEx != nil && meter == nil && meterCurrent == nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.ChargerEx
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases != nil && chargerEx != nil && meter != nil && meterCurrent == nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.ChargerEx
+			api.Meter
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases != nil && chargerEx != nil && meter == nil && meterCurrent != nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.ChargerEx
+			api.MeterCurrent
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+
+	case chargePhases != nil && chargerEx != nil && meter != nil && meterCurrent != nil && meterEnergy == nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.ChargerEx
+			api.Meter
+			api.MeterCurrent
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+
+	case chargePhases != nil && chargerEx != nil && meter == nil && meterCurrent != nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.ChargerEx
+			api.MeterCurrent
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case chargePhases != nil && chargerEx != nil && meter != nil && meterCurrent != nil && meterEnergy != nil:
+		return &struct {
+			*Modbus
+			api.ChargePhases
+			api.ChargerEx
+			api.Meter
+			api.MeterCurrent
+			api.MeterEnergy
+		}{
+			Modbus: base,
+			ChargePhases: &decorateModbusChargePhasesImpl{
+				chargePhases: chargePhases,
+			},
+			ChargerEx: &decorateModbusChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateModbusMeterImpl{
+				meter: meter,
+			},
+			MeterCurrent: &decorateModbusMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+			MeterEnergy: &decorateModbusMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+	}
+
+	return nil
+}
+
+type decorateModbusChargePhasesImpl struct {
+	chargePhases func(phases int) error
+}
+
+func (impl *decorateModbusChargePhasesImpl) Phases1p3p(phases int) error {
+	return impl.chargePhases(phases)
+}
+
+type decorateModbusChargerExImpl struct {
+	chargerEx func(current float64) error
+}
+
+func (impl *decorateModbusChargerExImpl) MaxCurrentMillis(current float64) error {
+	return impl.chargerEx(current)
+}
+
+type decorateModbusMeterImpl struct {
+	meter func() (float64, error)
+}
+
+func (impl *decorateModbusMeterImpl) CurrentPower() (float64, error) {
+	return impl.meter()
+}
+
+type decorateModbusMeterCurrentImpl struct {
+	meterCurrent func() (float64, float64, float64, error)
+}
+
+func (impl *decorateModbusMeterCurrentImpl) Currents() (float64, float64, float64, error) {
+	return impl.meterCurrent()
+}
+
+type decorateModbusMeterEnergyImpl struct {
+	meterEnergy func() (float64, error)
+}
+
+func (impl *decorateModbusMeterEnergyImpl) TotalEnergy() (float64, error) {
+	return impl.meterEnergy()
+}
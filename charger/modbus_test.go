@@ -0,0 +1,102 @@
+package charger
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+func TestModbus(t *testing.T) {
+	wbc, err := NewModbusFromConfig(map[string]interface{}{
+		"uri": "192.0.2.2:502",
+		"status": map[string]interface{}{
+			"address": 100, "type": "input",
+			"map": map[string]interface{}{"1": "a", "2": "b", "3": "c"},
+		},
+		"enable":     map[string]interface{}{"address": 400, "type": "coil"},
+		"maxcurrent": map[string]interface{}{"address": 528, "type": "holding", "scale": 10},
+		"power":      map[string]interface{}{"address": 120, "type": "input", "decode": "int32"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := wbc.(api.Meter); !ok {
+		t.Error("missing Meter api")
+	}
+
+	if _, ok := wbc.(api.ChargerEx); !ok {
+		t.Error("missing ChargerEx api")
+	}
+
+	if _, ok := wbc.(api.MeterEnergy); ok {
+		t.Error("unexpected MeterEnergy api")
+	}
+
+	if _, ok := wbc.(api.ChargePhases); ok {
+		t.Error("unexpected ChargePhases api")
+	}
+}
+
+func TestModbusInvalidRegister(t *testing.T) {
+	_, err := NewModbusFromConfig(map[string]interface{}{
+		"uri":        "192.0.2.2:502",
+		"status":     map[string]interface{}{"address": 100, "type": "input"},
+		"enable":     map[string]interface{}{"address": 400, "type": "coil"},
+		"maxcurrent": map[string]interface{}{"address": 528, "type": "foo"},
+	})
+
+	if err == nil {
+		t.Error("expected invalid register type error")
+	}
+}
+
+func TestModbusWriteOnlyEnable(t *testing.T) {
+	conf := map[string]interface{}{
+		"uri":        "192.0.2.2:502",
+		"status":     map[string]interface{}{"address": 100, "type": "input"},
+		"enable":     map[string]interface{}{"address": 400, "type": "writesingle"},
+		"maxcurrent": map[string]interface{}{"address": 528, "type": "holding"},
+	}
+
+	if _, err := NewModbusFromConfig(conf); err == nil {
+		t.Error("expected missing enabled register error")
+	}
+
+	conf["enabled"] = map[string]interface{}{"address": 401, "type": "holding"}
+	if _, err := NewModbusFromConfig(conf); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModbusPhases(t *testing.T) {
+	conf := func(phases map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"uri":        "192.0.2.2:502",
+			"status":     map[string]interface{}{"address": 100, "type": "input"},
+			"enable":     map[string]interface{}{"address": 400, "type": "coil"},
+			"maxcurrent": map[string]interface{}{"address": 528, "type": "holding", "decode": "uint32"},
+			"phases":     phases,
+		}
+	}
+
+	wbc, err := NewModbusFromConfig(conf(map[string]interface{}{
+		"address": 600, "type": "holding",
+		"map": map[string]interface{}{"1": 0, "3": 1},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wb := wbc.(api.ChargePhases)
+	if err := wb.Phases1p3p(2); err == nil {
+		t.Error("expected invalid phases error")
+	}
+
+	if _, err := NewModbusFromConfig(conf(map[string]interface{}{
+		"address": 600, "type": "holding",
+		"map": map[string]interface{}{"2": 0},
+	})); err == nil {
+		t.Error("expected invalid map key error")
+	}
+}
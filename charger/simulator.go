@@ -0,0 +1,113 @@
+package charger
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/simulator"
+)
+
+// Simulator is a simulated IEC 61851 charger with bidirectional charging support.
+// Vehicle and site are simulated by the referenced simulation.
+type Simulator struct {
+	sim *simulator.Simulation
+}
+
+func init() {
+	registry.Add("simulator", NewSimulatorFromConfig)
+}
+
+// NewSimulatorFromConfig creates a simulated charger from generic config
+func NewSimulatorFromConfig(other map[string]interface{}) (api.Charger, error) {
+	cc := struct {
+		Simulation           string
+		simulator.EVSEConfig `mapstructure:",squash"`
+	}{
+		EVSEConfig: simulator.DefaultEVSEConfig(),
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	sim := simulator.Instance(cc.Simulation)
+	if err := sim.ConfigureEVSE(cc.EVSEConfig); err != nil {
+		return nil, err
+	}
+
+	return &Simulator{sim: sim}, nil
+}
+
+// Status implements the api.Charger interface
+func (wb *Simulator) Status() (api.ChargeStatus, error) {
+	return wb.sim.Status(), nil
+}
+
+// Enabled implements the api.Charger interface
+func (wb *Simulator) Enabled() (bool, error) {
+	return wb.sim.Enabled(), nil
+}
+
+// Enable implements the api.Charger interface
+func (wb *Simulator) Enable(enable bool) error {
+	return wb.sim.Enable(enable)
+}
+
+// MaxCurrent implements the api.Charger interface
+func (wb *Simulator) MaxCurrent(current int64) error {
+	return wb.sim.SetCurrent(float64(current))
+}
+
+var _ api.FaultReporter = (*Simulator)(nil)
+
+// FaultDetail implements the api.FaultReporter interface
+func (wb *Simulator) FaultDetail() (string, error) {
+	return wb.sim.FaultDetail(), nil
+}
+
+var _ api.ChargerEx = (*Simulator)(nil)
+
+// MaxCurrentMillis implements the api.ChargerEx interface
+func (wb *Simulator) MaxCurrentMillis(current float64) error {
+	return wb.sim.SetCurrent(current)
+}
+
+var _ api.ChargePhases = (*Simulator)(nil)
+
+// Phases1p3p implements the api.ChargePhases interface
+func (wb *Simulator) Phases1p3p(phases int) error {
+	return wb.sim.SetPhases(phases)
+}
+
+var _ api.ChargerDischarge = (*Simulator)(nil)
+
+// MaxDischargeCurrent implements the api.ChargerDischarge interface
+func (wb *Simulator) MaxDischargeCurrent(current float64) error {
+	return wb.sim.SetDischargeCurrent(current)
+}
+
+// DischargePower implements the api.ChargerDischarge interface
+func (wb *Simulator) DischargePower() (float64, error) {
+	return wb.sim.DischargePower(), nil
+}
+
+var _ api.Meter = (*Simulator)(nil)
+
+// CurrentPower implements the api.Meter interface
+func (wb *Simulator) CurrentPower() (float64, error) {
+	return wb.sim.ChargePower(), nil
+}
+
+var _ api.MeterEnergy = (*Simulator)(nil)
+
+// TotalEnergy implements the api.MeterEnergy interface
+func (wb *Simulator) TotalEnergy() (float64, error) {
+	return wb.sim.ChargedEnergy(), nil
+}
+
+var _ api.MeterCurrent = (*Simulator)(nil)
+
+// Currents implements the api.MeterCurrent interface
+func (wb *Simulator) Currents() (float64, float64, float64, error) {
+	i1, i2, i3 := wb.sim.Currents()
+	return i1, i2, i3, nil
+}
@@ -0,0 +1,20 @@
+package charger
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/templates"
+)
+
+func init() {
+	registry.Add("template", NewTemplateFromConfig)
+}
+
+// NewTemplateFromConfig creates a charger from a parameterized device template
+func NewTemplateFromConfig(other map[string]interface{}) (api.Charger, error) {
+	typ, conf, err := templates.Instantiate(templates.Charger, other)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFromConfig(typ, conf)
+}
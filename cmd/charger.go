@@ -1,6 +1,12 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/server"
 	"github.com/evcc-io/evcc/util"
@@ -12,11 +18,100 @@ import (
 var chargerCmd = &cobra.Command{
 	Use:   "charger [name]",
 	Short: "Query configured chargers",
-	Run:   runCharger,
+	Long: `Query configured chargers. Commands like --enable or --current are sent
+before the charger is queried and require selecting a single charger by name.`,
+	Run: runCharger,
+}
+
+var chargerFlags struct {
+	enable  bool
+	disable bool
+	current string
+	phases  int
+	watch   time.Duration
 }
 
 func init() {
 	rootCmd.AddCommand(chargerCmd)
+
+	flags := chargerCmd.Flags()
+	flags.BoolVar(&chargerFlags.enable, "enable", false, "Enable charger")
+	flags.BoolVar(&chargerFlags.disable, "disable", false, "Disable charger")
+	flags.StringVar(&chargerFlags.current, "current", "", "Set current in A or mA, e.g. 16, 6.5A or 6500mA")
+	flags.IntVar(&chargerFlags.phases, "phases", 0, "Switch phases (1 or 3)")
+	flags.DurationVarP(&chargerFlags.watch, "watch", "w", 0, "Repeat query in interval")
+}
+
+// parseCurrent parses a current in A or mA and returns amps
+func parseCurrent(s string) (float64, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+
+	scale := 1.0
+	if strings.HasSuffix(v, "ma") {
+		v = strings.TrimSuffix(v, "ma")
+		scale = 1e-3
+	} else {
+		v = strings.TrimSuffix(v, "a")
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || f < 0 {
+		return 0, fmt.Errorf("invalid current: %s", s)
+	}
+
+	return f * scale, nil
+}
+
+// chargerCommands sends the requested commands to the charger
+func chargerCommands(c api.Charger) error {
+	if chargerFlags.enable && chargerFlags.disable {
+		return errors.New("cannot enable and disable at the same time")
+	}
+
+	if chargerFlags.disable {
+		if err := c.Enable(false); err != nil {
+			return fmt.Errorf("disable: %w", err)
+		}
+	}
+
+	if chargerFlags.phases > 0 {
+		cc, ok := c.(api.ChargePhases)
+		if !ok {
+			return fmt.Errorf("phases: %w", api.ErrNotAvailable)
+		}
+		if chargerFlags.phases != 1 && chargerFlags.phases != 3 {
+			return fmt.Errorf("phases: invalid value %d", chargerFlags.phases)
+		}
+		if err := cc.Phases1p3p(chargerFlags.phases); err != nil {
+			return fmt.Errorf("phases: %w", err)
+		}
+	}
+
+	if chargerFlags.current != "" {
+		current, err := parseCurrent(chargerFlags.current)
+
+		if err == nil {
+			if current == float64(int64(current)) {
+				err = c.MaxCurrent(int64(current))
+			} else if cc, ok := c.(api.ChargerEx); ok {
+				err = cc.MaxCurrentMillis(current)
+			} else {
+				err = fmt.Errorf("%.3gA: %w", current, api.ErrNotAvailable)
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("current: %w", err)
+		}
+	}
+
+	if chargerFlags.enable {
+		if err := c.Enable(true); err != nil {
+			return fmt.Errorf("enable: %w", err)
+		}
+	}
+
+	return nil
 }
 
 func runCharger(cmd *cobra.Command, args []string) {
@@ -44,8 +139,22 @@ func runCharger(cmd *cobra.Command, args []string) {
 		chargers = map[string]api.Charger{arg: cp.Charger(arg)}
 	}
 
-	d := dumper{len: len(chargers)}
-	for name, v := range chargers {
-		d.DumpWithHeader(name, v)
+	if chargerFlags.enable || chargerFlags.disable || chargerFlags.current != "" || chargerFlags.phases > 0 {
+		if len(chargers) != 1 {
+			log.FATAL.Fatal("commands require a single charger, specify charger name")
+		}
+
+		for name, v := range chargers {
+			if err := chargerCommands(v); err != nil {
+				log.FATAL.Fatalf("%s: %v", name, err)
+			}
+		}
 	}
+
+	d := dumper{len: len(chargers)}
+	d.Watch(chargerFlags.watch, func() {
+		for name, v := range chargers {
+			d.DumpWithHeader(name, v)
+		}
+	})
 }
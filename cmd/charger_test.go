@@ -0,0 +1,34 @@
+package cmd
+
+import "testing"
+
+func TestParseCurrent(t *testing.T) {
+	tc := []struct {
+		in  string
+		res float64
+		err string
+	}{
+		{"16", 16, ""},
+		{"6.5A", 6.5, ""},
+		{" 6500 mA ", 6.5, ""},
+		{"6500ma", 6.5, ""},
+		{"foo", 0, "invalid current: foo"},
+		{"10kA", 0, "invalid current: 10kA"},
+		{"-6A", 0, "invalid current: -6A"},
+	}
+
+	for _, tc := range tc {
+		res, err := parseCurrent(tc.in)
+
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("%s: expected error %s, got %v", tc.in, tc.err, err)
+			}
+			continue
+		}
+
+		if err != nil || res != tc.res {
+			t.Errorf("%s: expected %v, got %v (%v)", tc.in, tc.res, res, err)
+		}
+	}
+}
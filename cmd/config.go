@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -24,6 +25,9 @@ type config struct {
 	Interval     time.Duration
 	Mqtt         mqttConfig
 	Javascript   map[string]interface{}
+	TokenStore   tokenStoreConfig
+	ChargeCurves string
+	Sessions     string
 	Influx       server.InfluxConfig
 	EEBus        map[string]interface{}
 	HEMS         typedConfig
@@ -53,6 +57,11 @@ type qualifiedConfig struct {
 	Other      map[string]interface{} `mapstructure:",remain"`
 }
 
+type tokenStoreConfig struct {
+	File   string
+	Secret string
+}
+
 type typedConfig struct {
 	Type  string
 	Other map[string]interface{} `mapstructure:",remain"`
@@ -112,6 +121,28 @@ func (cp *ConfigProvider) configure(conf config) error {
 	return err
 }
 
+// close releases all devices holding connections
+func (cp *ConfigProvider) close() {
+	var devices []interface{}
+	for _, d := range cp.meters {
+		devices = append(devices, d)
+	}
+	for _, d := range cp.chargers {
+		devices = append(devices, d)
+	}
+	for _, d := range cp.vehicles {
+		devices = append(devices, d)
+	}
+
+	for _, d := range devices {
+		if d, ok := d.(io.Closer); ok {
+			if err := d.Close(); err != nil {
+				log.ERROR.Println(err)
+			}
+		}
+	}
+}
+
 func (cp *ConfigProvider) configureMeters(conf config) error {
 	cp.meters = make(map[string]api.Meter)
 	for id, cc := range conf.Meters {
@@ -130,6 +161,7 @@ func (cp *ConfigProvider) configureMeters(conf config) error {
 		}
 
 		cp.meters[cc.Name] = m
+		meter.AddInstance(cc.Name, m)
 	}
 
 	return nil
@@ -0,0 +1,391 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger"
+	"github.com/evcc-io/evcc/core"
+	"github.com/evcc-io/evcc/meter"
+	"github.com/evcc-io/evcc/provider"
+	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/schema"
+	"github.com/evcc-io/evcc/vehicle"
+	"github.com/evcc-io/evcc/vehicle/wrapper"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Schema is the configuration JSON schema
+var Schema []byte
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configuration tools",
+}
+
+// configCheckCmd represents the config check command
+var configCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Validate configuration",
+	Long: `Validate the configuration file against the configuration schema,
+check for unknown keys and resolve all meter, charger and vehicle references.
+Device and nested provider configurations are decoded without connecting to the devices.
+Using --probe, each device is created and queried once.`,
+	Run: runConfigCheck,
+}
+
+var configProbe bool
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configCheckCmd)
+
+	configCheckCmd.Flags().BoolVar(&configProbe, "probe", false, "Create and query each device once")
+}
+
+// configChecker collects configuration problems
+type configChecker struct {
+	problems []string
+}
+
+func (c *configChecker) fail(area string, err error) {
+	// report decoding errors one by one
+	var me *mapstructure.Error
+	if errors.As(err, &me) {
+		for _, e := range me.Errors {
+			c.problems = append(c.problems, fmt.Sprintf("%s: %s", area, strings.TrimPrefix(e, "'' ")))
+		}
+		return
+	}
+
+	c.problems = append(c.problems, fmt.Sprintf("%s: %v", area, err))
+}
+
+// checkSchema validates the config file against the schema
+func (c *configChecker) checkSchema(file string) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		c.fail("config", err)
+		return
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		c.fail("config", err)
+		return
+	}
+
+	s, err := schema.New(Schema)
+	if err != nil {
+		c.fail("schema", err)
+		return
+	}
+
+	for _, err := range s.Validate(doc) {
+		c.fail("schema", err)
+	}
+}
+
+// decodeMeter decodes the meter configuration without creating the meter
+func decodeMeter(typ string, other map[string]interface{}) error {
+	_, err := meter.NewFromConfig(typ, other)
+	return err
+}
+
+// decodeCharger decodes the charger configuration without creating the charger
+func decodeCharger(typ string, other map[string]interface{}) error {
+	_, err := charger.NewFromConfig(typ, other)
+	return err
+}
+
+// decodeVehicle decodes the vehicle configuration without creating the vehicle
+func decodeVehicle(typ string, other map[string]interface{}) error {
+	v, err := vehicle.NewFromConfig(typ, other)
+
+	// initialization errors are captured by the wrapper
+	if w, ok := v.(*wrapper.Wrapper); ok {
+		_, err = w.SoC()
+	}
+
+	return err
+}
+
+// checkDevices validates device names, types and configurations and returns the defined names.
+// Device configurations are decoded without connecting to the devices.
+func (c *configChecker) checkDevices(class string, devices []qualifiedConfig, types []string, decode func(string, map[string]interface{}) error) map[string]bool {
+	res := make(map[string]bool)
+
+	for id, cc := range devices {
+		area := fmt.Sprintf("%s %d", class, id+1)
+		if cc.Name != "" {
+			area = fmt.Sprintf("%s '%s'", class, cc.Name)
+		}
+
+		if cc.Name == "" {
+			c.fail(area, errors.New("missing name"))
+		} else if res[cc.Name] {
+			c.fail(area, errors.New("duplicate name"))
+		}
+		res[cc.Name] = true
+
+		var found bool
+		for _, typ := range types {
+			if strings.EqualFold(typ, cc.Type) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			c.fail(area, fmt.Errorf("invalid type: %s", cc.Type))
+			continue
+		}
+
+		if err := decode(cc.Type, util.DecodeOnly(cc.Other)); err != nil && !errors.Is(err, util.ErrDecodeOnly) {
+			c.fail(area, err)
+		}
+
+		// nested providers are not decoded by the device
+		c.checkProviders(area, "", cc.Other)
+	}
+
+	return res
+}
+
+// checkProviders recursively decodes all provider configurations contained in conf without creating the providers
+func (c *configChecker) checkProviders(area, path string, conf interface{}) {
+	switch conf := conf.(type) {
+	case map[string]interface{}:
+		if _, ok := conf["source"]; ok {
+			c.checkProvider(fmt.Sprintf("%s: %s", area, path), conf)
+		}
+
+		keys := make([]string, 0, len(conf))
+		for k := range conf {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			key := k
+			if path != "" {
+				key = path + "." + k
+			}
+			c.checkProviders(area, key, conf[k])
+		}
+
+	case map[interface{}]interface{}:
+		c.checkProviders(area, path, cast.ToStringMap(conf))
+
+	case []interface{}:
+		for i, v := range conf {
+			c.checkProviders(area, fmt.Sprintf("%s[%d]", path, i), v)
+		}
+	}
+}
+
+// checkProvider decodes the provider configuration without creating the provider
+func (c *configChecker) checkProvider(area string, other map[string]interface{}) {
+	var cc provider.Config
+	if err := util.DecodeOther(other, &cc); err != nil {
+		c.fail(area, err)
+		return
+	}
+
+	cc.Other = util.DecodeOnly(cc.Other)
+
+	// string getters support all provider types
+	if _, err := provider.NewStringGetterFromConfig(cc); err != nil && !errors.Is(err, util.ErrDecodeOnly) {
+		c.fail(area, err)
+	}
+}
+
+// checkRef validates that a referenced device is defined
+func (c *configChecker) checkRef(area, class, ref string, names map[string]bool) {
+	if ref != "" && !names[ref] {
+		c.fail(area, fmt.Errorf("%s not defined: %s", class, ref))
+	}
+}
+
+// checkReferences decodes devices, site and loadpoints, reporting unknown keys and undefined references
+func (c *configChecker) checkReferences(conf config) {
+	meters := c.checkDevices("meter", conf.Meters, meter.Types(), decodeMeter)
+	chargers := c.checkDevices("charger", conf.Chargers, charger.Types(), decodeCharger)
+	vehicles := c.checkDevices("vehicle", conf.Vehicles, vehicle.Types(), decodeVehicle)
+
+	site := core.NewSite()
+	if err := util.DecodeOther(conf.Site, &site); err != nil {
+		c.fail("site", err)
+	}
+
+	// decoding continues after unknown keys
+	if site != nil {
+		c.checkRef("site", "meter", site.Meters.GridMeterRef, meters)
+		c.checkRef("site", "meter", site.Meters.PVMeterRef, meters)
+		c.checkRef("site", "meter", site.Meters.BatteryMeterRef, meters)
+	}
+
+	for id, lpc := range conf.LoadPoints {
+		area := fmt.Sprintf("loadpoint %d", id+1)
+
+		lp := core.NewLoadPoint(log)
+		if err := util.DecodeOther(lpc, &lp); err != nil {
+			c.fail(area, err)
+		}
+
+		if lp == nil || lp.ChargerRef == "" {
+			c.fail(area, errors.New("missing charger"))
+			continue
+		}
+
+		c.checkRef(area, "charger", lp.ChargerRef, chargers)
+		c.checkRef(area, "meter", lp.Meters.ChargeMeterRef, meters)
+		c.checkRef(area, "vehicle", lp.VehicleRef, vehicles)
+
+		for _, ref := range lp.VehiclesRef {
+			c.checkRef(area, "vehicle", ref, vehicles)
+		}
+	}
+}
+
+// probeResult is the outcome of querying a single device
+type probeResult struct {
+	class, name, result string
+	err                 error
+}
+
+// probe creates and queries each device once
+func probe(conf config) []probeResult {
+	var res []probeResult
+
+	for _, cc := range conf.Meters {
+		r := probeResult{class: "meter", name: cc.Name}
+
+		m, err := meter.NewFromConfig(cc.Type, cc.Other)
+		if err == nil {
+			meter.AddInstance(cc.Name, m)
+
+			var power float64
+			if power, err = m.CurrentPower(); err == nil {
+				r.result = fmt.Sprintf("%.0fW", power)
+			}
+		}
+
+		r.err = err
+		res = append(res, r)
+	}
+
+	for _, cc := range conf.Chargers {
+		r := probeResult{class: "charger", name: cc.Name}
+
+		c, err := charger.NewFromConfig(cc.Type, cc.Other)
+		if err == nil {
+			var status api.ChargeStatus
+			if status, err = c.Status(); err == nil {
+				var enabled bool
+				if enabled, err = c.Enabled(); err == nil {
+					r.result = fmt.Sprintf("status %s, enabled %s", status, truefalse[enabled])
+				}
+			}
+		}
+
+		r.err = err
+		res = append(res, r)
+	}
+
+	for _, cc := range conf.Vehicles {
+		r := probeResult{class: "vehicle", name: cc.Name}
+
+		v, err := vehicle.NewFromConfig(cc.Type, cc.Other)
+		if err == nil {
+			var soc float64
+			if soc, err = v.SoC(); err == nil {
+				r.result = fmt.Sprintf("soc %.0f%%", soc)
+			} else if errors.Is(err, api.ErrMustRetry) {
+				r.result, err = "waking up", nil
+			}
+		}
+
+		r.err = err
+		res = append(res, r)
+	}
+
+	return res
+}
+
+func runConfigCheck(cmd *cobra.Command, args []string) {
+	// log levels from the config file are validated, not applied
+	util.LogLevel(cmd.Flag("log").Value.String(), nil)
+	log.INFO.Printf("evcc %s (%s)", server.Version, server.Commit)
+
+	if cfgFile == "" {
+		log.FATAL.Fatal("missing evcc config")
+	}
+
+	fmt.Println("checking", cfgFile)
+
+	c := new(configChecker)
+	c.checkSchema(cfgFile)
+
+	// unknown top level keys
+	var conf config
+	if err := viper.UnmarshalExact(&conf); err != nil {
+		c.fail("config", err)
+
+		// continue checking known keys
+		if err := viper.Unmarshal(&conf); err != nil {
+			c.fail("config", err)
+		}
+	}
+
+	c.checkReferences(conf)
+
+	for _, p := range c.problems {
+		fmt.Println(p)
+	}
+
+	failed := len(c.problems)
+
+	if configProbe && failed == 0 {
+		if err := configureEnvironment(conf); err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		res := probe(conf)
+		sort.SliceStable(res, func(i, j int) bool {
+			return res[i].class < res[j].class
+		})
+
+		fmt.Println()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+		for _, r := range res {
+			if r.err != nil {
+				failed++
+				fmt.Fprintf(w, "%s\t%s:\tfailed\t%v\n", r.class, r.name, r.err)
+			} else {
+				fmt.Fprintf(w, "%s\t%s:\tok\t%s\n", r.class, r.name, r.result)
+			}
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	if failed > 0 {
+		fmt.Printf("%d problem(s) found\n", failed)
+		os.Exit(1)
+	}
+
+	fmt.Println("config ok")
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigCheckDevices(t *testing.T) {
+	devices := []qualifiedConfig{
+		{
+			Name: "wb",
+			Type: "custom",
+			Other: map[string]interface{}{
+				"status": map[string]interface{}{
+					"source": "http",
+					"uri":    "http://localhost",
+					"jqq":    ".status",
+				},
+				"enabled": map[string]interface{}{
+					"source": "calc",
+					"add": []interface{}{
+						map[string]interface{}{"source": "script", "cmd": "true", "foo": "bar"},
+					},
+				},
+				"enable": map[string]interface{}{
+					"source": "script",
+					"cmd":    "true",
+				},
+				"maxcurrent": map[string]interface{}{
+					"source": "script",
+					"cmd":    "true",
+				},
+				"foo": "bar",
+			},
+		},
+	}
+
+	c := new(configChecker)
+	c.checkDevices("charger", devices, []string{"custom"}, decodeCharger)
+
+	expect := []string{
+		"charger 'wb': has invalid keys: foo",
+		"charger 'wb': enabled.add[0]: has invalid keys: foo",
+		"charger 'wb': status: has invalid keys: jqq",
+	}
+
+	if !reflect.DeepEqual(c.problems, expect) {
+		t.Errorf("expected %v, got %v", expect, c.problems)
+	}
+}
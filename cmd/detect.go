@@ -9,6 +9,7 @@ import (
 
 	"github.com/evcc-io/evcc/detect"
 	"github.com/evcc-io/evcc/detect/tasks"
+	"github.com/evcc-io/evcc/templates"
 	"github.com/evcc-io/evcc/util"
 	"github.com/korylprince/ipnetgen"
 	"github.com/olekukonko/tablewriter"
@@ -96,6 +97,8 @@ func display(res []tasks.Result) {
 	fmt.Println("")
 	table.Render()
 
+	suggest(res)
+
 	fmt.Println(`
 Please open https://github.com/evcc-io/evcc/issues/new in your browser and copy the
 results above into a new issue. Please tell us:
@@ -104,6 +107,28 @@ results above into a new issue. Please tell us:
 	2. If not correct: please describe your hardware setup.`)
 }
 
+// suggest prints template configurations for detected devices
+func suggest(res []tasks.Result) {
+	for _, hit := range res {
+		values := map[string]interface{}{
+			"host": hit.ResultDetails.IP,
+		}
+
+		if hit.ResultDetails.Port > 0 {
+			values["port"] = hit.ResultDetails.Port
+		}
+
+		if hit.ResultDetails.ModbusResult != nil {
+			values["id"] = hit.ResultDetails.ModbusResult.SlaveID
+		}
+
+		for _, tmpl := range templates.ByDetect(hit.ID) {
+			fmt.Printf("\n# %s %s (%s)\n", tmpl.Class, tmpl.Description, hit.ResultDetails.IP)
+			fmt.Print(tmpl.Usage(values))
+		}
+	}
+}
+
 func runDetect(cmd *cobra.Command, args []string) {
 	util.LogLevel(viper.GetString("log"), nil)
 
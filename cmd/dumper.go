@@ -16,6 +16,7 @@ var truefalse = map[bool]string{false: "false", true: "true"}
 
 type dumper struct {
 	len int
+	raw bool // no headers, e.g. for json output
 }
 
 func (d *dumper) Header(name, underline string) {
@@ -35,6 +36,24 @@ func (d *dumper) DumpWithHeader(name string, device interface{}) {
 	}
 }
 
+// Watch calls dump once or, if interval is set, repeatedly with a timestamp header
+func (d *dumper) Watch(interval time.Duration, dump func()) {
+	for {
+		if interval > 0 && !d.raw {
+			d.Header(time.Now().Format(time.RFC3339), "=")
+			fmt.Println()
+		}
+
+		dump()
+
+		if interval == 0 {
+			return
+		}
+
+		time.Sleep(interval)
+	}
+}
+
 func (d *dumper) Dump(name string, v interface{}) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
 
@@ -158,6 +177,20 @@ func (d *dumper) Dump(name string, v interface{}) {
 		}
 	}
 
+	if v, ok := v.(api.VehiclePosition); ok {
+		if lat, lon, err := v.Position(); err != nil {
+			fmt.Fprintf(w, "Position:\t%v\n", err)
+		} else {
+			fmt.Fprintf(w, "Position:\t%.5f, %.5f\n", lat, lon)
+		}
+	}
+
+	if v, ok := v.(api.CircuitBreaker); ok {
+		if state := v.CircuitState(); state != "" {
+			fmt.Fprintf(w, "Api state:\t%s\n", state)
+		}
+	}
+
 	// Identity
 
 	if v, ok := v.(api.Identifier); ok {
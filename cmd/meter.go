@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/server"
 	"github.com/evcc-io/evcc/util"
@@ -15,8 +17,11 @@ var meterCmd = &cobra.Command{
 	Run:   runMeter,
 }
 
+var meterWatch time.Duration
+
 func init() {
 	rootCmd.AddCommand(meterCmd)
+	meterCmd.Flags().DurationVarP(&meterWatch, "watch", "w", 0, "Repeat query in interval")
 }
 
 func runMeter(cmd *cobra.Command, args []string) {
@@ -45,7 +50,9 @@ func runMeter(cmd *cobra.Command, args []string) {
 	}
 
 	d := dumper{len: len(meters)}
-	for name, v := range meters {
-		d.DumpWithHeader(name, v)
-	}
+	d.Watch(meterWatch, func() {
+		for name, v := range meters {
+			d.DumpWithHeader(name, v)
+		}
+	})
 }
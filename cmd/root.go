@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/evcc-io/evcc/provider/javascript"
 	"github.com/evcc-io/evcc/server"
 	"github.com/evcc-io/evcc/server/updater"
 	"github.com/evcc-io/evcc/util"
@@ -171,6 +172,9 @@ func run(cmd *cobra.Command, args []string) {
 	cache := util.NewCache()
 	go cache.Run(pipe.NewDropper(ignoreErrors...).Pipe(tee.Attach()))
 
+	// expose cached values to javascript
+	javascript.SetCache(cache)
+
 	// setup database
 	if conf.Influx.URL != "" {
 		configureDatabase(conf.Influx, site.LoadPoints(), tee.Attach())
@@ -247,6 +251,8 @@ func run(cmd *cobra.Command, args []string) {
 		close(stopC) // signal loop to end
 		<-exitC      // wait for loop to end
 
+		cp.close() // release device connections
+
 		os.Exit(1)
 	}()
 
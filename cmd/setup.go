@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -13,6 +15,8 @@ import (
 	"github.com/evcc-io/evcc/api/proto/pb"
 	"github.com/evcc-io/evcc/core"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/hems"
 	"github.com/evcc-io/evcc/provider/javascript"
 	"github.com/evcc-io/evcc/provider/mqtt"
@@ -21,6 +25,7 @@ import (
 	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/cloud"
+	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/pipe"
 	"github.com/evcc-io/evcc/util/sponsor"
 	"github.com/spf13/viper"
@@ -61,6 +66,21 @@ func configureEnvironment(conf config) (err error) {
 		err = configureJavascript(conf.Javascript)
 	}
 
+	// setup token store
+	if err == nil && conf.TokenStore != (tokenStoreConfig{}) {
+		configureTokenStore(conf.TokenStore)
+	}
+
+	// setup charge curves
+	if err == nil {
+		configureChargeCurves(conf.ChargeCurves)
+	}
+
+	// setup sessions
+	if err == nil {
+		configureSessions(conf.Sessions)
+	}
+
 	// setup EEBus server
 	if err == nil && conf.EEBus != nil {
 		err = configureEEBus(conf.EEBus)
@@ -145,6 +165,57 @@ func configureJavascript(conf map[string]interface{}) error {
 	return nil
 }
 
+// setup token store, tokens are not persisted on errors
+func configureTokenStore(conf tokenStoreConfig) {
+	if conf.File == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			log.WARN.Printf("token store disabled: %v", err)
+			return
+		}
+
+		conf.File = filepath.Join(home, ".evcc", "tokens")
+	}
+
+	if err := oauth.ConfigureStore(conf.File, conf.Secret); err != nil {
+		log.ERROR.Printf("%v, tokens are not persisted", err)
+	}
+}
+
+// setup learned charge curves
+func configureChargeCurves(file string) {
+	if file == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			log.WARN.Printf("charge curves not persisted: %v", err)
+			return
+		}
+
+		file = filepath.Join(home, ".evcc", "chargecurves.json")
+	}
+
+	if err := soc.ConfigureCurves(file); err != nil {
+		log.WARN.Printf("charge curves: %v", err)
+	}
+}
+
+// setup charging sessions
+func configureSessions(file string) {
+	if file == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			log.WARN.Printf("sessions not persisted: %v", err)
+			return
+		}
+
+		file = filepath.Join(home, ".evcc", "sessions.json")
+	}
+
+	if err := session.Configure(file); err != nil {
+		log.WARN.Printf("sessions: %v", err)
+	}
+}
+
 // setup HEMS
 func configureHEMS(conf typedConfig, site *core.Site, cache *util.Cache, httpd *server.HTTPd) hems.HEMS {
 	hems, err := hems.NewFromConfig(conf.Type, conf.Other, site, cache, httpd)
@@ -19,7 +19,10 @@ func usage() {
 soc
 
 Usage:
-  soc brand [--log level] [--param value [...]]
+  soc type [--log level] [--action soc|start|stop|wakeup|climate-on|climate-off] [--param value [...]]
+
+Cloud vehicles are created using type cloud and the --brand parameter.
+Use evcc vehicle --type type for querying all vehicle capabilities.
 `)
 }
 
@@ -29,8 +32,8 @@ func main() {
 		log.Fatal("not enough arguments")
 	}
 
+	typ := strings.ToLower(os.Args[1])
 	params := make(map[string]interface{})
-	params["brand"] = strings.ToLower(os.Args[1])
 
 	action := "soc"
 
@@ -56,43 +59,74 @@ func main() {
 		log.Fatal("unexpected number of parameters")
 	}
 
-	v, err := vehicle.NewCloudFromConfig(params)
+	v, err := vehicle.NewFromConfig(typ, params)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	switch action {
-	case "wakeup":
+	case "wakeup", "start":
 		vv, ok := v.(api.VehicleStartCharge)
 		if !ok {
 			log.Fatal("not supported:", action)
 		}
-		if err := vv.StartCharge(); err != nil {
+		if err := retry(vv.StartCharge); err != nil {
+			log.Fatal(err)
+		}
+
+	case "stop":
+		vv, ok := v.(api.VehicleStopCharge)
+		if !ok {
+			log.Fatal("not supported:", action)
+		}
+		if err := retry(vv.StopCharge); err != nil {
+			log.Fatal(err)
+		}
+
+	case "climate-on", "climate-off":
+		vv, ok := v.(api.VehicleClimateController)
+		if !ok {
+			log.Fatal("not supported:", action)
+		}
+
+		command := vv.StartClimate
+		if action == "climate-off" {
+			command = vv.StopClimate
+		}
+
+		if err := retry(command); err != nil {
 			log.Fatal(err)
 		}
 
 	case "soc":
-		start := time.Now()
-		for {
-			if time.Since(start) > time.Minute {
-				log.Fatal(api.ErrTimeout)
-			}
-
-			soc, err := v.SoC()
-			if err != nil {
-				if errors.Is(err, api.ErrMustRetry) {
-					time.Sleep(5 * time.Second)
-					continue
-				}
-
-				log.Fatal(err)
-			}
-
-			fmt.Println(int(math.Round(soc)))
-			break
+		var soc float64
+		if err := retry(func() (err error) {
+			soc, err = v.SoC()
+			return err
+		}); err != nil {
+			log.Fatal(err)
 		}
 
+		fmt.Println(int(math.Round(soc)))
+
 	default:
 		log.Fatal("invalid action:", action)
 	}
 }
+
+// retry repeats the command while the vehicle is not ready
+func retry(command func() error) error {
+	start := time.Now()
+	for {
+		err := command()
+		if !errors.Is(err, api.ErrMustRetry) {
+			return err
+		}
+
+		if time.Since(start) > time.Minute {
+			return api.ErrTimeout
+		}
+
+		time.Sleep(5 * time.Second)
+	}
+}
@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/server"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/vehicle"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -16,20 +21,250 @@ import (
 var vehicleCmd = &cobra.Command{
 	Use:   "vehicle [name]",
 	Short: "Query configured vehicles",
-	Run:   runVehicle,
+	Long: `Query configured vehicles or an ad-hoc vehicle created using --type and --param.
+Commands like --start or --climate are sent before the vehicle is queried.`,
+	Run: runVehicle,
+}
+
+var vehicleFlags struct {
+	typ       string
+	params    map[string]string
+	json      bool
+	watch     time.Duration
+	start     bool
+	stop      bool
+	wakeup    bool
+	climate   string
+	targetSoC int
 }
 
 func init() {
 	rootCmd.AddCommand(vehicleCmd)
+
+	flags := vehicleCmd.Flags()
+	flags.StringVarP(&vehicleFlags.typ, "type", "t", "", "Create ad-hoc vehicle of type instead of using configured vehicles")
+	flags.StringToStringVarP(&vehicleFlags.params, "param", "p", nil, "Ad-hoc vehicle parameter, e.g. --param user=foo")
+	flags.BoolVar(&vehicleFlags.json, "json", false, "Output JSON, one object per vehicle and line")
+	flags.DurationVarP(&vehicleFlags.watch, "watch", "w", 0, "Repeat query in interval")
+	flags.BoolVar(&vehicleFlags.start, "start", false, "Start charging")
+	flags.BoolVar(&vehicleFlags.stop, "stop", false, "Stop charging")
+	flags.BoolVar(&vehicleFlags.wakeup, "wakeup", false, "Wake up vehicle")
+	flags.StringVar(&vehicleFlags.climate, "climate", "", "Start (on) or stop (off) climate control")
+	flags.IntVar(&vehicleFlags.targetSoC, "targetsoc", 0, "Set vehicle target SoC")
+}
+
+// vehicleState is the JSON representation of all vehicle capabilities
+type vehicleState struct {
+	Timestamp  time.Time         `json:"timestamp"`
+	Name       string            `json:"name"`
+	Title      string            `json:"title"`
+	Capacity   int64             `json:"capacity"`
+	SoC        *float64          `json:"soc,omitempty"`
+	Range      *int64            `json:"range,omitempty"`
+	Status     api.ChargeStatus  `json:"status,omitempty"`
+	FinishTime *time.Time        `json:"finishTime,omitempty"`
+	Climate    *climateState     `json:"climate,omitempty"`
+	Odometer   *float64          `json:"odometer,omitempty"`
+	Position   *positionState    `json:"position,omitempty"`
+	Circuit    string            `json:"circuit,omitempty"`
+	Errors     map[string]string `json:"errors,omitempty"`
+}
+
+type climateState struct {
+	Active      bool     `json:"active"`
+	OutsideTemp *float64 `json:"outsideTemp,omitempty"`
+	TargetTemp  *float64 `json:"targetTemp,omitempty"`
+}
+
+type positionState struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// queryVehicle collects all capabilities exposed by the vehicle
+func queryVehicle(name string, v api.Vehicle) vehicleState {
+	res := vehicleState{
+		Timestamp: time.Now(),
+		Name:      name,
+		Title:     v.Title(),
+		Capacity:  v.Capacity(),
+		Errors:    make(map[string]string),
+	}
+
+	fail := func(key string, err error) {
+		res.Errors[key] = err.Error()
+	}
+
+	if soc, err := v.SoC(); err == nil {
+		res.SoC = &soc
+	} else {
+		fail("soc", err)
+	}
+
+	if v, ok := v.(api.VehicleRange); ok {
+		if rng, err := v.Range(); err == nil {
+			res.Range = &rng
+		} else {
+			fail("range", err)
+		}
+	}
+
+	if v, ok := v.(api.ChargeState); ok {
+		if status, err := v.Status(); err == nil {
+			res.Status = status
+		} else {
+			fail("status", err)
+		}
+	}
+
+	if v, ok := v.(api.VehicleFinishTimer); ok {
+		if ft, err := v.FinishTime(); err == nil {
+			res.FinishTime = &ft
+		} else {
+			fail("finishTime", err)
+		}
+	}
+
+	if v, ok := v.(api.VehicleClimater); ok {
+		if active, ot, tt, err := v.Climater(); err == nil {
+			res.Climate = &climateState{Active: active}
+			if !math.IsNaN(ot) {
+				res.Climate.OutsideTemp = &ot
+			}
+			if !math.IsNaN(tt) {
+				res.Climate.TargetTemp = &tt
+			}
+		} else {
+			fail("climate", err)
+		}
+	}
+
+	if v, ok := v.(api.VehicleOdometer); ok {
+		if odo, err := v.Odometer(); err == nil {
+			res.Odometer = &odo
+		} else {
+			fail("odometer", err)
+		}
+	}
+
+	if v, ok := v.(api.VehiclePosition); ok {
+		if lat, lon, err := v.Position(); err == nil {
+			res.Position = &positionState{Lat: lat, Lon: lon}
+		} else {
+			fail("position", err)
+		}
+	}
+
+	if v, ok := v.(api.CircuitBreaker); ok {
+		res.Circuit = v.CircuitState()
+	}
+
+	if len(res.Errors) == 0 {
+		res.Errors = nil
+	}
+
+	return res
+}
+
+// vehicleCommands sends the requested commands to the vehicle
+func vehicleCommands(v api.Vehicle) error {
+	// repeat commands while the vehicle is waking up
+	retry := func(cmd func() error) error {
+		err := cmd()
+		if errors.Is(err, api.ErrMustRetry) {
+			if err = waitForVehicle(v); err == nil {
+				err = cmd()
+			}
+		}
+		return err
+	}
+
+	unsupported := func(cmd string) error {
+		return fmt.Errorf("%s: %w", cmd, api.ErrNotAvailable)
+	}
+
+	if vehicleFlags.wakeup || vehicleFlags.start {
+		vv, ok := v.(api.VehicleStartCharge)
+		if !ok {
+			return unsupported("start")
+		}
+		if err := retry(vv.StartCharge); err != nil {
+			return fmt.Errorf("start: %w", err)
+		}
+	}
+
+	if vehicleFlags.stop {
+		vv, ok := v.(api.VehicleStopCharge)
+		if !ok {
+			return unsupported("stop")
+		}
+		if err := retry(vv.StopCharge); err != nil {
+			return fmt.Errorf("stop: %w", err)
+		}
+	}
+
+	if vehicleFlags.targetSoC > 0 {
+		vv, ok := v.(api.VehicleChargeLimiter)
+		if !ok {
+			return unsupported("targetsoc")
+		}
+		if err := retry(func() error { return vv.SetTargetSoC(vehicleFlags.targetSoC) }); err != nil {
+			return fmt.Errorf("targetsoc: %w", err)
+		}
+	}
+
+	if vehicleFlags.climate != "" {
+		vv, ok := v.(api.VehicleClimateController)
+		if !ok {
+			return unsupported("climate")
+		}
+
+		var err error
+		switch strings.ToLower(vehicleFlags.climate) {
+		case "on", "start", "true":
+			err = retry(vv.StartClimate)
+		case "off", "stop", "false":
+			err = retry(vv.StopClimate)
+		default:
+			err = fmt.Errorf("invalid value: %s", vehicleFlags.climate)
+		}
+
+		if err != nil {
+			return fmt.Errorf("climate: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// waitForVehicle waits up to 1m for the vehicle to wakeup
+func waitForVehicle(v api.Vehicle) error {
+	start := time.Now()
+
+	for {
+		if time.Since(start) > time.Minute {
+			return api.ErrTimeout
+		}
+
+		_, err := v.SoC()
+		if err == nil || !errors.Is(err, api.ErrMustRetry) {
+			return err
+		}
+
+		time.Sleep(5 * time.Second)
+		if !vehicleFlags.json {
+			fmt.Print(".")
+		}
+	}
 }
 
 func runVehicle(cmd *cobra.Command, args []string) {
 	util.LogLevel(viper.GetString("log"), viper.GetStringMapString("levels"))
 	log.INFO.Printf("evcc %s (%s)", server.Version, server.Commit)
 
-	// load config
+	// load config, optional for ad-hoc vehicles
 	conf, err := loadConfigFile(cfgFile)
-	if err != nil {
+	if err != nil && vehicleFlags.typ == "" {
 		log.FATAL.Fatal(err)
 	}
 
@@ -38,43 +273,56 @@ func runVehicle(cmd *cobra.Command, args []string) {
 		log.FATAL.Fatal(err)
 	}
 
-	if err := cp.configureVehicles(conf); err != nil {
-		log.FATAL.Fatal(err)
+	var vehicles map[string]api.Vehicle
+
+	if vehicleFlags.typ != "" {
+		other := make(map[string]interface{}, len(vehicleFlags.params))
+		for k, v := range vehicleFlags.params {
+			other[k] = v
+		}
+
+		v, err := vehicle.NewFromConfig(vehicleFlags.typ, other)
+		if err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		vehicles = map[string]api.Vehicle{vehicleFlags.typ: v}
+	} else {
+		if err := cp.configureVehicles(conf); err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		vehicles = cp.vehicles
+		if len(args) == 1 {
+			arg := args[0]
+			vehicles = map[string]api.Vehicle{arg: cp.Vehicle(arg)}
+		}
 	}
 
-	vehicles := cp.vehicles
-	if len(args) == 1 {
-		arg := args[0]
-		vehicles = map[string]api.Vehicle{arg: cp.Vehicle(arg)}
+	for name, v := range vehicles {
+		if err := vehicleCommands(v); err != nil {
+			log.ERROR.Printf("%s: %v", name, err)
+		}
 	}
 
-	d := dumper{len: len(vehicles)}
-NEXT:
 	for name, v := range vehicles {
-		start := time.Now()
-
-	WAIT:
-		// wait up to 1m for the vehicle to wakeup
-		for {
-			if time.Since(start) > time.Minute {
-				log.ERROR.Println(api.ErrTimeout)
-				continue NEXT
-			}
+		if err := waitForVehicle(v); err != nil {
+			log.ERROR.Printf("%s: %v", name, err)
+		}
+	}
 
-			if _, err := v.SoC(); err != nil {
-				if errors.Is(err, api.ErrMustRetry) {
-					time.Sleep(5 * time.Second)
-					fmt.Print(".")
-					continue WAIT
-				}
+	enc := json.NewEncoder(os.Stdout)
+	d := dumper{len: len(vehicles), raw: vehicleFlags.json}
 
-				log.ERROR.Println(err)
-				continue NEXT
+	d.Watch(vehicleFlags.watch, func() {
+		for name, v := range vehicles {
+			if vehicleFlags.json {
+				if err := enc.Encode(queryVehicle(name, v)); err != nil {
+					log.FATAL.Fatal(err)
+				}
+			} else {
+				d.DumpWithHeader(name, v)
 			}
-
-			break
 		}
-
-		d.DumpWithHeader(name, v)
-	}
+	})
 }
@@ -39,8 +39,8 @@ func (lp *vehicleCoordinator) availableVehicles(owner interface{}, vehicles []ap
 	return res
 }
 
-// find active vehicle by charge state
-func (lp *vehicleCoordinator) identifyVehicleByStatus(log *util.Logger, owner interface{}, vehicles []api.Vehicle) api.Vehicle {
+// find active vehicle by charge state, ignoring vehicles outside the home geofence
+func (lp *vehicleCoordinator) identifyVehicleByStatus(log *util.Logger, owner interface{}, vehicles []api.Vehicle, home *Geofence) api.Vehicle {
 	available := lp.availableVehicles(owner, vehicles)
 
 	var res api.Vehicle
@@ -57,6 +57,10 @@ func (lp *vehicleCoordinator) identifyVehicleByStatus(log *util.Logger, owner in
 
 			// vehicle is plugged or charging, so it should be the right one
 			if status == api.StatusB || status == api.StatusC {
+				if !vehicleAtHome(log, home, vehicle) {
+					continue
+				}
+
 				if res != nil {
 					log.DEBUG.Printf("vehicle status: >1 matches, giving up")
 					return nil
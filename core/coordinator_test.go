@@ -48,7 +48,7 @@ func TestVehicleDetectByStatus(t *testing.T) {
 		v1.MockVehicle.EXPECT().Title().Return("v1")
 		v2.MockVehicle.EXPECT().Title().Return("v2")
 
-		res := c.identifyVehicleByStatus(log, lp, vehicles)
+		res := c.identifyVehicleByStatus(log, lp, vehicles, nil)
 		if tc.res != res {
 			t.Errorf("expected %v, got %v", tc.res, res)
 		}
@@ -61,3 +61,67 @@ func TestVehicleDetectByStatus(t *testing.T) {
 	}
 
 }
+
+func TestVehicleDetectByPosition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	type vehicle struct {
+		*mock.MockVehicle
+		*mock.MockChargeState
+		*mock.MockVehiclePosition
+	}
+
+	v1 := &vehicle{mock.NewMockVehicle(ctrl), mock.NewMockChargeState(ctrl), mock.NewMockVehiclePosition(ctrl)}
+	v2 := &vehicle{mock.NewMockVehicle(ctrl), mock.NewMockChargeState(ctrl), mock.NewMockVehiclePosition(ctrl)}
+
+	home := &Geofence{Lat: 52.52, Lon: 13.405, Radius: 500}
+
+	type pos struct{ lat, lon float64 }
+	away := pos{48.137, 11.575}
+	near := pos{52.521, 13.406}
+
+	type testcase struct {
+		string
+		p1, p2 pos
+		res    api.Vehicle
+	}
+	tc := []testcase{
+		{"home/away->1", near, away, v1},
+		{"away/home->2", away, near, v2},
+		{"away/away->0", away, away, nil},
+		{"home/home->0", near, near, nil},
+	}
+
+	log := util.NewLogger("foo")
+	vehicles := []api.Vehicle{v1, v2}
+
+	lp := &LoadPoint{}
+	c := &vehicleCoordinator{make(map[api.Vehicle]interface{})}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		v1.MockChargeState.EXPECT().Status().Return(api.StatusB, nil)
+		v2.MockChargeState.EXPECT().Status().Return(api.StatusB, nil)
+		v1.MockVehicle.EXPECT().Title().Return("v1").AnyTimes()
+		v2.MockVehicle.EXPECT().Title().Return("v2").AnyTimes()
+		v1.MockVehiclePosition.EXPECT().Position().Return(tc.p1.lat, tc.p1.lon, nil)
+		v2.MockVehiclePosition.EXPECT().Position().Return(tc.p2.lat, tc.p2.lon, nil)
+
+		if res := c.identifyVehicleByStatus(log, lp, vehicles, home); tc.res != res {
+			t.Errorf("expected %v, got %v", tc.res, res)
+		}
+	}
+}
+
+func TestGeofenceContains(t *testing.T) {
+	home := &Geofence{Lat: 52.52, Lon: 13.405, Radius: 500}
+
+	if !home.Contains(52.523, 13.405) { // ~330m
+		t.Error("expected position inside geofence")
+	}
+
+	if home.Contains(52.53, 13.405) { // ~1.1km
+		t.Error("expected position outside geofence")
+	}
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"errors"
+	"math"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+const earthRadius = 6371e3 // m
+
+// Geofence is the circular home area used for identifying vehicles
+type Geofence struct {
+	Lat, Lon float64
+	Radius   float64 // m
+}
+
+// Contains checks if the position is inside the geofence
+func (g *Geofence) Contains(lat, lon float64) bool {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	// haversine distance
+	dLat := rad(lat - g.Lat)
+	dLon := rad(lon - g.Lon)
+
+	a := math.Pow(math.Sin(dLat/2), 2) + math.Cos(rad(g.Lat))*math.Cos(rad(lat))*math.Pow(math.Sin(dLon/2), 2)
+	dist := 2 * earthRadius * math.Asin(math.Sqrt(a))
+
+	return dist <= g.Radius
+}
+
+// vehicleAtHome checks if the vehicle position is inside the geofence.
+// Vehicles without geofence or known position are considered at home.
+func vehicleAtHome(log *util.Logger, home *Geofence, vehicle api.Vehicle) bool {
+	vp, ok := vehicle.(api.VehiclePosition)
+	if home == nil || !ok {
+		return true
+	}
+
+	lat, lon, err := vp.Position()
+	if err != nil {
+		if !errors.Is(err, api.ErrNotAvailable) {
+			log.ERROR.Printf("vehicle position: %v", err)
+		}
+		return true
+	}
+
+	res := home.Contains(lat, lon)
+	log.DEBUG.Printf("vehicle position: %.5f, %.5f (home: %v, %s)", lat, lon, res, vehicle.Title())
+
+	return res
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/core/wrapper"
 	"github.com/evcc-io/evcc/provider"
@@ -96,6 +97,9 @@ type LoadPoint struct {
 	OnDisconnect    ActionConfig            `mapstructure:"onDisconnect"`
 	OnIdentify      map[string]ActionConfig `mapstructure:"onIdentify"`
 	Enable, Disable ThresholdConfig
+	Discharge       DischargeConfig
+	Fault           FaultConfig
+	Departure       DepartureConfig
 
 	MinCurrent    float64       // PV mode: start current	Min+PV mode: min current
 	MaxCurrent    float64       // Max allowed current. Physically ensured by the charger
@@ -110,6 +114,16 @@ type LoadPoint struct {
 	vehicleConnectedTicker *clock.Ticker
 	vehicleID              string
 
+	vehicleTargetSoC        int       // Target soc synced to the vehicle
+	vehicleTargetSoCFailed  int       // Target soc failed to sync
+	vehicleTargetSoCUpdated time.Time // Target soc sync timestamp
+	wakeupTimer             time.Time // Vehicle wake-up timestamp
+	wakeupAttempts          int       // Vehicle wake-up attempts
+	vehicleStopped          bool      // Vehicle charging stopped by charge stop command
+
+	preconditioning       bool      // Vehicle preconditioning started for departure
+	preconditionDeparture time.Time // Departure preconditioning has been started for
+
 	charger     api.Charger
 	chargeTimer api.ChargeTimer
 	chargeRater api.ChargeRater
@@ -117,6 +131,7 @@ type LoadPoint struct {
 	chargeMeter  api.Meter     // Charger usage meter
 	vehicle      api.Vehicle   // Currently active vehicle
 	vehicles     []api.Vehicle // Assigned vehicles
+	home         *Geofence     // Site home area
 	socEstimator *soc.Estimator
 	socTimer     *soc.Timer
 
@@ -131,12 +146,30 @@ type LoadPoint struct {
 
 	// charge progress
 	vehicleSoc              float64       // Vehicle SoC
+	vehicleOdometer         float64       // Vehicle odometer, 0 if unknown
 	chargeDuration          time.Duration // Charge duration
 	chargedEnergy           float64       // Charged energy while connected in Wh
 	chargeRemainingDuration time.Duration // Remaining charge duration
 	chargeRemainingEnergy   float64       // Remaining charge energy in Wh
 
-	tasks []func() error // task list for repeated execution
+	// discharge progress
+	dischargeCurrent float64   // Charger discharge current limit
+	dischargePower   float64   // Discharging power
+	dischargedEnergy float64   // Discharged energy while connected in Wh
+	dischargeUpdated time.Time // Discharge power updated timestamp
+
+	// charger fault tracking
+	faultCount    int           // Number of faults since startup
+	faultDuration time.Duration // Accumulated duration of recovered faults
+	faultStart    time.Time     // Current fault start timestamp
+	faultStep     int           // Next recovery action
+	faultNext     time.Time     // Next recovery action timestamp
+	faultReenable time.Time     // Pending charger re-enable after cycling
+
+	tasks    []func() error // task list for repeated execution
+	commands sync.WaitGroup // running vehicle commands
+
+	session *session.Session // current charging session
 }
 
 // NewLoadPointFromConfig creates a new loadpoint
@@ -203,6 +236,15 @@ func NewLoadPointFromConfig(log *util.Logger, cp configProvider, other map[strin
 	}
 	lp.charger = cp.Charger(lp.ChargerRef)
 	lp.configureChargerType(lp.charger)
+	lp.configureDischarge()
+
+	if err := lp.configureFault(); err != nil {
+		return nil, err
+	}
+
+	if err := lp.configureDeparture(); err != nil {
+		return nil, err
+	}
 
 	// allow target charge handler to access loadpoint
 	lp.socTimer = soc.NewTimer(lp.log, &adapter{LoadPoint: lp})
@@ -318,6 +360,7 @@ func (lp *LoadPoint) evVehicleConnectHandler() {
 	// energy
 	lp.chargedEnergy = 0
 	lp.publish("chargedEnergy", lp.chargedEnergy)
+	lp.resetDischargedEnergy()
 
 	// duration
 	lp.connectedTime = lp.clock.Now()
@@ -326,6 +369,9 @@ func (lp *LoadPoint) evVehicleConnectHandler() {
 	// soc update reset
 	lp.socUpdated = time.Time{}
 
+	// vehicle settings may have been changed while disconnected
+	lp.resetVehicleSync()
+
 	// soc update reset on car change
 	if lp.socEstimator != nil {
 		lp.socEstimator.Reset()
@@ -338,6 +384,9 @@ func (lp *LoadPoint) evVehicleConnectHandler() {
 	// identify active vehicle
 	lp.startVehicleDetection()
 
+	// record session
+	lp.startSession()
+
 	// immediately allow pv mode activity
 	lp.elapsePVTimer()
 
@@ -350,10 +399,19 @@ func (lp *LoadPoint) evVehicleDisconnectHandler() {
 
 	// energy and duration
 	lp.publish("chargedEnergy", lp.chargedEnergy)
+	lp.publish("dischargedEnergy", lp.dischargedEnergy)
 	lp.publish("connectedDuration", lp.clock.Since(lp.connectedTime))
 
 	lp.pushEvent(evVehicleDisconnect)
 
+	// learn charge efficiency from session
+	if lp.socEstimator != nil {
+		lp.socEstimator.Complete(lp.chargedEnergy - lp.dischargedEnergy)
+	}
+
+	// record session
+	lp.stopSession()
+
 	// remove active vehicle
 	if len(lp.vehicles) > 1 {
 		lp.setActiveVehicle(nil)
@@ -429,11 +487,16 @@ func (lp *LoadPoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Even
 	lp.publish("phases", lp.Phases)
 	lp.publish("activePhases", lp.activePhases)
 	lp.publish("hasVehicle", len(lp.vehicles) > 0)
+	lp.publish("dischargeConfigured", lp.Discharge.Enable)
+	lp.publish("chargerFault", "")
+	lp.publish("chargerFaultCount", lp.faultCount)
+	lp.publish("preconditioning", false)
 
 	lp.Lock()
 	lp.publish("mode", lp.Mode)
 	lp.publish("targetSoC", lp.SoC.Target)
 	lp.publish("minSoC", lp.SoC.Min)
+	lp.publish("departureTime", lp.Departure.Time)
 	lp.Unlock()
 
 	// always treat single vehicle as attached to allow poll mode: always
@@ -509,14 +572,6 @@ func (lp *LoadPoint) setLimit(chargeCurrent float64, force bool) (err error) {
 			return nil
 		}
 
-		// sleep vehicle
-		if car, ok := lp.vehicle.(api.VehicleStopCharge); !enabled && ok {
-			// log but don't propagate
-			if err := car.StopCharge(); err != nil {
-				lp.log.ERROR.Printf("vehicle remote charge stop: %v", err)
-			}
-		}
-
 		lp.log.DEBUG.Printf("charger %s", status[enabled])
 		if err = lp.charger.Enable(enabled); err == nil {
 			lp.enabled = enabled
@@ -524,12 +579,11 @@ func (lp *LoadPoint) setLimit(chargeCurrent float64, force bool) (err error) {
 
 			lp.bus.Publish(evChargeCurrent, chargeCurrent)
 
-			// wake up vehicle
-			if car, ok := lp.vehicle.(api.VehicleStartCharge); enabled && ok {
-				// log but don't propagate
-				if err := car.StartCharge(); err != nil {
-					lp.log.ERROR.Printf("vehicle remote charge start: %v", err)
-				}
+			// sleep vehicle or resume charging of vehicles stopped before
+			if enabled {
+				lp.startVehicle()
+			} else {
+				lp.stopVehicle()
 			}
 		} else {
 			err = fmt.Errorf("charger %s: %w", status[enabled], err)
@@ -594,7 +648,7 @@ func (lp *LoadPoint) climateActive() bool {
 			}
 
 			lp.publish("climater", status)
-			return active
+			return active || lp.preconditioning
 		}
 
 		if !errors.Is(err, api.ErrNotAvailable) {
@@ -602,7 +656,7 @@ func (lp *LoadPoint) climateActive() bool {
 		}
 	}
 
-	return false
+	return lp.preconditioning
 }
 
 // remoteControlled returns true if remote control status is active
@@ -693,21 +747,31 @@ func (lp *LoadPoint) setActiveVehicle(vehicle api.Vehicle) {
 	}
 	lp.log.INFO.Printf("vehicle updated: %s -> %s", from, to)
 
+	lp.resetVehicleSync()
+
 	if lp.vehicle = vehicle; vehicle != nil {
 		lp.socEstimator = soc.NewEstimator(lp.log, lp.charger, vehicle, lp.SoC.Estimate)
+		lp.socEstimator.SetCurve(soc.LoadCurve(to))
 
 		lp.publish("vehiclePresent", true)
 		lp.publish("vehicleTitle", lp.vehicle.Title())
 		lp.publish("vehicleCapacity", lp.vehicle.Capacity())
 
 		lp.task(lp.odometer)
+
+		// record vehicle identified during session
+		if lp.session != nil {
+			lp.task(lp.sessionVehicle)
+		}
 	} else {
 		lp.socEstimator = nil
 
 		lp.publish("vehiclePresent", false)
 		lp.publish("vehicleTitle", "")
 		lp.publish("vehicleCapacity", int64(0))
+		lp.vehicleOdometer = 0
 		lp.publish("vehicleOdometer", 0.0)
+		lp.publish("vehicleApiState", "")
 	}
 }
 
@@ -740,7 +804,7 @@ func (lp *LoadPoint) identifyVehicleByStatus() {
 		return
 	}
 
-	if vehicle := coordinator.identifyVehicleByStatus(lp.log, lp, lp.vehicles); vehicle != nil {
+	if vehicle := coordinator.identifyVehicleByStatus(lp.log, lp, lp.vehicles, lp.home); vehicle != nil {
 		lp.setActiveVehicle(vehicle)
 		return
 	}
@@ -1133,7 +1197,15 @@ func (lp *LoadPoint) socPollAllowed() bool {
 		lp.log.DEBUG.Printf("next soc poll remaining time: %v", remaining.Truncate(time.Second))
 	}
 
-	return lp.charging() || honourUpdateInterval && (remaining <= 0) || lp.connected() && lp.socUpdated.IsZero()
+	allowed := lp.charging() || honourUpdateInterval && (remaining <= 0) || lp.connected() && lp.socUpdated.IsZero()
+
+	// don't poll vehicles away from home
+	if allowed && !vehicleAtHome(lp.log, lp.home, lp.vehicle) {
+		lp.log.DEBUG.Printf("vehicle not at home, skipping soc poll")
+		return false
+	}
+
+	return allowed
 }
 
 // checks if the connected charger can provide SoC to the connected vehicle
@@ -1152,16 +1224,24 @@ func (lp *LoadPoint) publishSoCAndRange() {
 		return
 	}
 
+	// vehicle api request limiter state
+	if cb, ok := lp.vehicle.(api.CircuitBreaker); ok {
+		lp.publish("vehicleApiState", cb.CircuitState())
+	}
+
 	if lp.socPollAllowed() || lp.socProvidedByCharger() {
 		lp.socUpdated = lp.clock.Now()
 
-		f, err := lp.socEstimator.SoC(lp.chargedEnergy)
+		f, err := lp.socEstimator.SoC(lp.chargedEnergy - lp.dischargedEnergy)
 		if err == nil {
 			lp.vehicleSoc = math.Trunc(f)
 			lp.log.DEBUG.Printf("vehicle soc: %.0f%%", lp.vehicleSoc)
 			lp.publish("vehicleSoc", lp.vehicleSoc)
 
 			if lp.charging() {
+				// learn charge curve
+				lp.socEstimator.Sample(lp.chargePower, lp.chargeCurrent*float64(lp.activePhases)*Voltage)
+
 				lp.setRemainingDuration(lp.socEstimator.RemainingChargeDuration(lp.chargePower, lp.SoC.Target))
 			} else {
 				lp.setRemainingDuration(-1)
@@ -1205,6 +1285,7 @@ func (lp *LoadPoint) Update(sitePower float64, cheap bool) {
 	// read and publish meters first
 	lp.updateChargePower()
 	lp.updateChargeCurrents()
+	lp.updateDischargePower()
 
 	// update ChargeRater here to make sure initial meter update is caught
 	lp.bus.Publish(evChargeCurrent, lp.chargeCurrent)
@@ -1216,6 +1297,7 @@ func (lp *LoadPoint) Update(sitePower float64, cheap bool) {
 	// read and publish status
 	if err := lp.updateChargerStatus(); err != nil {
 		lp.log.ERROR.Printf("charger: %v", err)
+		lp.stopDischarge()
 		return
 	}
 
@@ -1223,6 +1305,12 @@ func (lp *LoadPoint) Update(sitePower float64, cheap bool) {
 	lp.publish("charging", lp.charging())
 	lp.publish("enabled", lp.enabled)
 
+	// pause control while charger is faulted, pending vehicle commands are still completed
+	if lp.updateFault() {
+		lp.runTasks()
+		return
+	}
+
 	// identify connected vehicle
 	if lp.connected() {
 		// read identity and run associated action
@@ -1244,18 +1332,35 @@ func (lp *LoadPoint) Update(sitePower float64, cheap bool) {
 	// sync settings with charger
 	lp.syncCharger()
 
+	// sync target soc with vehicle
+	lp.syncVehicleTargetSoC()
+
+	// start or stop preconditioning for departure
+	lp.precondition()
+
 	// check if car connected and ready for charging
 	var err error
 
 	// track if remote disabled is actually active
 	remoteDisabled := loadpoint.RemoteEnable
 
+	// discharge current requested by pv mode
+	var dischargeCurrent float64
+
+	// stop discharging before charging may be enabled
+	setLimit := func(chargeCurrent float64, force bool) error {
+		if err := lp.setDischarge(0); err != nil {
+			return err
+		}
+		return lp.setLimit(chargeCurrent, force)
+	}
+
 	// execute loading strategy
 	switch {
 	case !lp.connected():
 		// always disable charger if not connected
 		// https://github.com/evcc-io/evcc/issues/105
-		err = lp.setLimit(0, false)
+		err = setLimit(0, false)
 
 	case lp.targetSocReached():
 		lp.log.DEBUG.Printf("targetSoC reached: %.1f > %d", lp.vehicleSoc, lp.SoC.Target)
@@ -1264,7 +1369,7 @@ func (lp *LoadPoint) Update(sitePower float64, cheap bool) {
 			lp.log.DEBUG.Println("climater active")
 			targetCurrent = lp.GetMinCurrent()
 		}
-		err = lp.setLimit(targetCurrent, true)
+		err = setLimit(targetCurrent, true)
 		lp.socTimer.Reset() // once SoC is reached, the target charge request is removed
 
 	// OCPP has priority over target charging
@@ -1273,25 +1378,25 @@ func (lp *LoadPoint) Update(sitePower float64, cheap bool) {
 		fallthrough
 
 	case mode == api.ModeOff:
-		err = lp.setLimit(0, true)
+		err = setLimit(0, true)
 
 	case lp.minSocNotReached():
 		// 3p if available
 		if err = lp.scalePhasesIfAvailable(3); err == nil {
-			err = lp.setLimit(lp.GetMaxCurrent(), true)
+			err = setLimit(lp.GetMaxCurrent(), true)
 		}
 		lp.elapsePVTimer() // let PV mode disable immediately afterwards
 
 	case mode == api.ModeNow:
 		// 3p if available
 		if err = lp.scalePhasesIfAvailable(3); err == nil {
-			err = lp.setLimit(lp.GetMaxCurrent(), true)
+			err = setLimit(lp.GetMaxCurrent(), true)
 		}
 
 	// target charging
 	case lp.socTimer.DemandActive() && false:
 		targetCurrent := lp.socTimer.Handle()
-		err = lp.setLimit(targetCurrent, true)
+		err = setLimit(targetCurrent, true)
 
 	case mode == api.ModeMinPV || mode == api.ModePV:
 		targetCurrent := lp.pvMaxCurrent(mode, sitePower)
@@ -1317,7 +1422,24 @@ func (lp *LoadPoint) Update(sitePower float64, cheap bool) {
 			required = true
 		}
 
-		err = lp.setLimit(targetCurrent, required)
+		// feed the house from the vehicle while pv charging is not possible
+		if mode == api.ModePV && targetCurrent == 0 && !required && lp.dischargeAllowed() {
+			dischargeCurrent = lp.dischargeMaxCurrent(sitePower)
+		}
+
+		if dischargeCurrent == 0 {
+			err = setLimit(targetCurrent, required)
+		} else {
+			err = lp.setLimit(targetCurrent, required)
+		}
+	}
+
+	// wake up vehicle if charging does not start
+	lp.wakeUpVehicle()
+
+	// discharging is only active if requested by pv mode
+	if derr := lp.setDischarge(dischargeCurrent); derr != nil {
+		lp.log.ERROR.Println(derr)
 	}
 
 	// effective disabled status
@@ -48,6 +48,10 @@ type API interface {
 
 	// SetTargetCharge sets the charge targetSoC
 	SetTargetCharge(time.Time, int)
+	// GetDepartureTime returns the daily departure time
+	GetDepartureTime() string
+	// SetDepartureTime sets the daily departure time
+	SetDepartureTime(string) error
 	// RemoteControl sets remote status demand
 	RemoteControl(string, RemoteDemand)
 
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -130,6 +131,36 @@ func (lp *LoadPoint) SetTargetCharge(finishAt time.Time, targetSoC int) {
 	lp.requestUpdate()
 }
 
+// GetDepartureTime returns loadpoint daily departure time
+func (lp *LoadPoint) GetDepartureTime() string {
+	lp.Lock()
+	defer lp.Unlock()
+	return lp.Departure.Time
+}
+
+// SetDepartureTime sets loadpoint daily departure time (hh:mm), empty to disable preconditioning
+func (lp *LoadPoint) SetDepartureTime(departure string) error {
+	if departure != "" {
+		if _, err := time.Parse(departureFormat, departure); err != nil {
+			return fmt.Errorf("invalid departure time: %s", departure)
+		}
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.INFO.Println("set departure time:", departure)
+
+	// apply immediately
+	if lp.Departure.Time != departure {
+		lp.Departure.Time = departure
+		lp.publish("departureTime", departure)
+		lp.requestUpdate()
+	}
+
+	return nil
+}
+
 // RemoteControl sets remote status demand
 func (lp *LoadPoint) RemoteControl(source string, demand loadpoint.RemoteDemand) {
 	lp.Lock()
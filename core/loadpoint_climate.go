@@ -0,0 +1,114 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+const (
+	departureFormat     = "15:04"
+	defaultPrecondition = 15 * time.Minute
+)
+
+// DepartureConfig defines the daily departure time used for vehicle preconditioning
+type DepartureConfig struct {
+	Time         string        `mapstructure:"time"`         // daily departure time (hh:mm), guarded by mutex
+	Precondition time.Duration `mapstructure:"precondition"` // preconditioning start before departure
+}
+
+// configureDeparture validates the departure time
+func (lp *LoadPoint) configureDeparture() error {
+	if lp.Departure.Precondition == 0 {
+		lp.Departure.Precondition = defaultPrecondition
+	}
+
+	if lp.Departure.Time == "" {
+		return nil
+	}
+
+	if _, err := time.Parse(departureFormat, lp.Departure.Time); err != nil {
+		return fmt.Errorf("invalid departure time: %s", lp.Departure.Time)
+	}
+
+	return nil
+}
+
+// nextDeparture returns the next departure. A target charging time takes precedence over the daily departure time.
+func (lp *LoadPoint) nextDeparture() time.Time {
+	now := lp.clock.Now()
+
+	if lp.socTimer != nil && lp.socTimer.Time.After(now) {
+		return lp.socTimer.Time
+	}
+
+	hm, err := time.Parse(departureFormat, lp.GetDepartureTime())
+	if err != nil {
+		return time.Time{}
+	}
+
+	departure := time.Date(now.Year(), now.Month(), now.Day(), hm.Hour(), hm.Minute(), 0, 0, now.Location())
+	if !departure.After(now) {
+		departure = departure.AddDate(0, 0, 1)
+	}
+
+	return departure
+}
+
+// setPreconditioning updates and publishes the preconditioning state
+func (lp *LoadPoint) setPreconditioning(active bool) {
+	lp.preconditioning = active
+	lp.publish("preconditioning", active)
+}
+
+// precondition starts vehicle climatisation before departure while the vehicle is connected,
+// so the required energy is taken from grid or pv instead of the vehicle battery.
+func (lp *LoadPoint) precondition() {
+	vc, ok := lp.vehicle.(api.VehicleClimateController)
+	if !ok {
+		if lp.preconditioning {
+			lp.setPreconditioning(false)
+		}
+		return
+	}
+
+	departure := lp.nextDeparture()
+	due := !departure.IsZero() && lp.connected() &&
+		!lp.clock.Now().Before(departure.Add(-lp.Departure.Precondition))
+
+	vehicle := lp.vehicle
+
+	switch {
+	// start once per departure
+	case due && !lp.preconditioning && !departure.Equal(lp.preconditionDeparture):
+		lp.preconditionDeparture = departure
+
+		lp.log.INFO.Printf("start preconditioning for departure at %s", departure.Format(departureFormat))
+		lp.setPreconditioning(true)
+
+		valid := func() bool {
+			return lp.preconditioning && lp.connected() && lp.vehicle == vehicle
+		}
+
+		lp.vehicleCommand("preconditioning", valid, vc.StartClimate, func(err error) {
+			if err != nil && valid() {
+				lp.setPreconditioning(false)
+			}
+		})
+
+	// departure has passed or vehicle has been disconnected
+	case !due && lp.preconditioning:
+		if lp.connected() {
+			lp.log.INFO.Println("stop preconditioning")
+
+			valid := func() bool {
+				return !lp.preconditioning && lp.connected() && lp.vehicle == vehicle
+			}
+
+			lp.vehicleCommand("preconditioning", valid, vc.StopClimate, nil)
+		}
+
+		lp.setPreconditioning(false)
+	}
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/soc"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+)
+
+func TestNextDeparture(t *testing.T) {
+	clock := clock.NewMock()
+	clock.Set(time.Date(2021, 10, 1, 8, 0, 0, 0, time.Local))
+
+	lp := &LoadPoint{
+		log:   util.NewLogger("foo"),
+		clock: clock,
+	}
+
+	if res := lp.nextDeparture(); !res.IsZero() {
+		t.Errorf("expected no departure, got %v", res)
+	}
+
+	// daily departure
+	lp.Departure.Time = "07:30"
+	if res, exp := lp.nextDeparture(), time.Date(2021, 10, 2, 7, 30, 0, 0, time.Local); !res.Equal(exp) {
+		t.Errorf("expected %v, got %v", exp, res)
+	}
+
+	lp.Departure.Time = "08:30"
+	if res, exp := lp.nextDeparture(), time.Date(2021, 10, 1, 8, 30, 0, 0, time.Local); !res.Equal(exp) {
+		t.Errorf("expected %v, got %v", exp, res)
+	}
+
+	// target charging time takes precedence
+	lp.socTimer = &soc.Timer{Time: time.Date(2021, 10, 1, 12, 0, 0, 0, time.Local)}
+	if res, exp := lp.nextDeparture(), lp.socTimer.Time; !res.Equal(exp) {
+		t.Errorf("expected %v, got %v", exp, res)
+	}
+}
+
+func TestPrecondition(t *testing.T) {
+	clock := clock.NewMock()
+	clock.Set(time.Date(2021, 10, 1, 7, 0, 0, 0, time.Local))
+	ctrl := gomock.NewController(t)
+
+	vehicle := struct {
+		*mock.MockVehicle
+		*mock.MockVehicleClimateController
+	}{
+		mock.NewMockVehicle(ctrl),
+		mock.NewMockVehicleClimateController(ctrl),
+	}
+
+	lp := &LoadPoint{
+		log:     util.NewLogger("foo"),
+		clock:   clock,
+		vehicle: vehicle,
+		status:  api.StatusB,
+		Departure: DepartureConfig{
+			Time:         "07:30",
+			Precondition: 15 * time.Minute,
+		},
+	}
+
+	// before preconditioning window
+	lp.precondition()
+	ctrl.Finish()
+
+	// start once
+	clock.Add(15 * time.Minute)
+	vehicle.MockVehicleClimateController.EXPECT().StartClimate().Return(nil)
+	lp.precondition()
+	waitTasks(lp)
+	lp.precondition()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	if !lp.climateActive() {
+		t.Error("expected climate active")
+	}
+
+	// stop after departure
+	clock.Add(15 * time.Minute)
+	vehicle.MockVehicleClimateController.EXPECT().StopClimate().Return(nil)
+	lp.precondition()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	if lp.preconditioning {
+		t.Error("expected preconditioning stopped")
+	}
+
+	// failed start is not repeated for the same departure
+	clock.Add(24*time.Hour - 15*time.Minute)
+	vehicle.MockVehicleClimateController.EXPECT().StartClimate().Return(errors.New("foo"))
+	lp.precondition()
+	waitTasks(lp)
+	lp.precondition()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	if lp.preconditioning {
+		t.Error("expected preconditioning stopped")
+	}
+
+	// pending start is dropped on disconnect
+	clock.Add(24 * time.Hour)
+	vehicle.MockVehicleClimateController.EXPECT().StartClimate().Return(api.ErrMustRetry)
+	lp.precondition()
+	lp.status = api.StatusA
+	lp.precondition()
+	waitTasks(lp)
+	ctrl.Finish()
+	lp.status = api.StatusB
+
+	if lp.preconditioning || len(lp.tasks) > 0 {
+		t.Error("expected preconditioning stopped")
+	}
+
+	// not started if disconnected
+	lp.status = api.StatusA
+	clock.Add(24 * time.Hour)
+	lp.precondition()
+	ctrl.Finish()
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// DischargeConfig defines bidirectional charging settings
+type DischargeConfig struct {
+	Enable bool `mapstructure:"enable"` // feed household consumption from the vehicle battery in pv mode
+	MinSoC int  `mapstructure:"minSoC"` // vehicle soc down to which the battery may be discharged
+}
+
+// configureDischarge validates the discharge configuration against charger and vehicle capabilities
+func (lp *LoadPoint) configureDischarge() {
+	if !lp.Discharge.Enable {
+		return
+	}
+
+	if _, ok := lp.charger.(api.ChargerDischarge); !ok {
+		lp.log.WARN.Println("discharge: charger does not support discharging")
+		lp.Discharge.Enable = false
+		return
+	}
+
+	if len(lp.vehicles) == 0 {
+		lp.log.WARN.Println("discharge: requires vehicle soc")
+		lp.Discharge.Enable = false
+		return
+	}
+
+	// never discharge below the minimum charge soc
+	if lp.Discharge.MinSoC < lp.SoC.Min {
+		lp.Discharge.MinSoC = lp.SoC.Min
+	}
+}
+
+// dischargeAllowed checks if the vehicle battery may be used to feed the house
+func (lp *LoadPoint) dischargeAllowed() bool {
+	return lp.Discharge.Enable &&
+		lp.connected() &&
+		lp.vehicle != nil &&
+		lp.vehicleSoc > float64(lp.Discharge.MinSoC)
+}
+
+// dischargeMaxCurrent calculates the discharge current that covers the site's grid import
+func (lp *LoadPoint) dischargeMaxCurrent(sitePower float64) float64 {
+	targetPower := lp.dischargePower + sitePower
+	targetCurrent := powerToCurrent(targetPower, lp.activePhases)
+
+	lp.log.DEBUG.Printf("max discharge current: %.3gA (%.0fW = %.0fW + %.0fW @ %dp)", targetCurrent, targetPower, lp.dischargePower, sitePower, lp.activePhases)
+
+	// avoid feeding the grid from the vehicle
+	if targetCurrent < lp.GetMinCurrent() {
+		return 0
+	}
+
+	return math.Min(targetCurrent, lp.GetMaxCurrent())
+}
+
+// setDischarge applies the charger discharge current, zero stops discharging
+func (lp *LoadPoint) setDischarge(current float64) error {
+	if current == lp.dischargeCurrent {
+		return nil
+	}
+
+	charger, ok := lp.charger.(api.ChargerDischarge)
+	if !ok {
+		return nil
+	}
+
+	if err := charger.MaxDischargeCurrent(current); err != nil {
+		return fmt.Errorf("max discharge current %.3g: %w", current, err)
+	}
+
+	switch {
+	case lp.dischargeCurrent == 0:
+		lp.log.INFO.Println("start discharging ->")
+	case current == 0:
+		lp.log.INFO.Println("stop discharging <-")
+	}
+
+	lp.dischargeCurrent = current
+	lp.log.DEBUG.Printf("max discharge current: %.3gA", current)
+	lp.publish("dischargeCurrent", current)
+
+	return nil
+}
+
+// stopDischarge stops discharging while the loadpoint is not under control
+func (lp *LoadPoint) stopDischarge() {
+	if err := lp.setDischarge(0); err != nil {
+		lp.log.ERROR.Printf("discharge: %v", err)
+	}
+}
+
+// updateDischargePower reads discharge power and accumulates the discharged energy
+func (lp *LoadPoint) updateDischargePower() {
+	charger, ok := lp.charger.(api.ChargerDischarge)
+	if !ok {
+		return
+	}
+
+	power, err := charger.DischargePower()
+	if err != nil {
+		lp.log.ERROR.Printf("discharge power: %v", err)
+		return
+	}
+
+	now := lp.clock.Now()
+	if !lp.dischargeUpdated.IsZero() {
+		lp.dischargedEnergy += (lp.dischargePower + power) / 2 * now.Sub(lp.dischargeUpdated).Hours()
+	}
+
+	lp.dischargePower = power
+	lp.dischargeUpdated = now
+
+	lp.log.DEBUG.Printf("discharge power: %.0fW", power)
+	lp.publish("dischargePower", power)
+	lp.publish("dischargedEnergy", lp.dischargedEnergy)
+}
+
+// resetDischargedEnergy starts a new discharge session
+func (lp *LoadPoint) resetDischargedEnergy() {
+	lp.dischargedEnergy = 0
+	lp.dischargeUpdated = time.Time{}
+	lp.publish("dischargedEnergy", lp.dischargedEnergy)
+}
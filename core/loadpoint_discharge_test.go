@@ -0,0 +1,203 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+)
+
+func TestDischargeMaxCurrent(t *testing.T) {
+	Voltage = 230 // V
+
+	tc := []struct {
+		dischargePower, sitePower, current float64
+	}{
+		{0, 0, 0},                     // balanced
+		{0, -1000, 0},                 // exporting
+		{0, 1 * Voltage * minA, 0},    // below min current at 3p
+		{0, 3 * Voltage * minA, minA}, // import covered
+		{3 * Voltage * minA, Voltage * 3, minA + 1},
+		{3 * Voltage * minA, -3 * Voltage * minA, 0}, // stop
+		{0, 3 * Voltage * 2 * maxA, maxA},            // capped
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		lp := &LoadPoint{
+			log:            util.NewLogger("foo"),
+			MinCurrent:     minA,
+			MaxCurrent:     maxA,
+			activePhases:   3,
+			dischargePower: tc.dischargePower,
+		}
+
+		if current := lp.dischargeMaxCurrent(tc.sitePower); current != tc.current {
+			t.Errorf("expected %.3gA, got %.3gA", tc.current, current)
+		}
+	}
+}
+
+func TestDischargeAllowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	vehicle := mock.NewMockVehicle(ctrl)
+
+	tc := []struct {
+		status api.ChargeStatus
+		soc    float64
+		res    bool
+	}{
+		{api.StatusA, 80, false},
+		{api.StatusB, 80, true},
+		{api.StatusB, 30, false},
+		{api.StatusB, 20, false},
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		lp := &LoadPoint{
+			log:        util.NewLogger("foo"),
+			status:     tc.status,
+			vehicle:    vehicle,
+			vehicleSoc: tc.soc,
+			Discharge: DischargeConfig{
+				Enable: true,
+				MinSoC: 30,
+			},
+		}
+
+		if res := lp.dischargeAllowed(); res != tc.res {
+			t.Errorf("expected %v, got %v", tc.res, res)
+		}
+	}
+}
+
+func TestDischargeEnergy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+
+	charger := &struct {
+		*mock.MockCharger
+		*mock.MockChargerDischarge
+	}{
+		mock.NewMockCharger(ctrl),
+		mock.NewMockChargerDischarge(ctrl),
+	}
+
+	lp := &LoadPoint{
+		log:     util.NewLogger("foo"),
+		clock:   clck,
+		charger: charger,
+	}
+
+	charger.MockChargerDischarge.EXPECT().MaxDischargeCurrent(minA).Return(nil)
+	if err := lp.setDischarge(minA); err != nil || lp.dischargeCurrent != minA {
+		t.Errorf("discharge current: %v %v", lp.dischargeCurrent, err)
+	}
+
+	// unchanged current is not sent again
+	if err := lp.setDischarge(minA); err != nil {
+		t.Error(err)
+	}
+
+	charger.MockChargerDischarge.EXPECT().DischargePower().Return(4000.0, nil).Times(2)
+	lp.updateDischargePower()
+	clck.Add(30 * time.Minute)
+	lp.updateDischargePower()
+
+	if lp.dischargedEnergy != 2000 {
+		t.Errorf("expected 2000Wh, got %.0fWh", lp.dischargedEnergy)
+	}
+
+	charger.MockChargerDischarge.EXPECT().MaxDischargeCurrent(0.0).Return(nil)
+	if err := lp.setDischarge(0); err != nil || lp.dischargeCurrent != 0 {
+		t.Errorf("discharge current: %v %v", lp.dischargeCurrent, err)
+	}
+}
+
+func TestDischargeStopOnChargerError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	charger := &struct {
+		*mock.MockCharger
+		*mock.MockChargerDischarge
+	}{
+		mock.NewMockCharger(ctrl),
+		mock.NewMockChargerDischarge(ctrl),
+	}
+
+	lp := &LoadPoint{
+		log:              util.NewLogger("foo"),
+		bus:              evbus.New(),
+		clock:            clock.NewMock(),
+		charger:          charger,
+		chargeMeter:      &Null{}, // silence nil panics
+		chargeRater:      &Null{}, // silence nil panics
+		chargeTimer:      &Null{}, // silence nil panics
+		MinCurrent:       minA,
+		MaxCurrent:       maxA,
+		status:           api.StatusB,
+		dischargeCurrent: minA,
+	}
+
+	charger.MockChargerDischarge.EXPECT().DischargePower().Return(0.0, nil)
+	charger.MockCharger.EXPECT().Status().Return(api.StatusNone, errors.New("timeout"))
+	charger.MockChargerDischarge.EXPECT().MaxDischargeCurrent(0.0).Return(nil)
+
+	lp.Update(0, false)
+
+	if lp.dischargeCurrent != 0 {
+		t.Errorf("expected discharge stopped, got %.3gA", lp.dischargeCurrent)
+	}
+}
+
+func TestDischargeStopBeforeCharging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	charger := &struct {
+		*mock.MockCharger
+		*mock.MockChargerDischarge
+	}{
+		mock.NewMockCharger(ctrl),
+		mock.NewMockChargerDischarge(ctrl),
+	}
+
+	lp := &LoadPoint{
+		log:              util.NewLogger("foo"),
+		bus:              evbus.New(),
+		clock:            clock.NewMock(),
+		charger:          charger,
+		chargeMeter:      &Null{}, // silence nil panics
+		chargeRater:      &Null{}, // silence nil panics
+		chargeTimer:      &Null{}, // silence nil panics
+		MinCurrent:       minA,
+		MaxCurrent:       maxA,
+		Mode:             api.ModeNow,
+		status:           api.StatusB,
+		dischargeCurrent: minA,
+	}
+
+	charger.MockChargerDischarge.EXPECT().DischargePower().Return(0.0, nil)
+	charger.MockCharger.EXPECT().Status().Return(api.StatusB, nil)
+	charger.MockCharger.EXPECT().Enabled().Return(false, nil).AnyTimes()
+
+	gomock.InOrder(
+		charger.MockChargerDischarge.EXPECT().MaxDischargeCurrent(0.0).Return(nil),
+		charger.MockCharger.EXPECT().MaxCurrent(int64(maxA)).Return(nil),
+		charger.MockCharger.EXPECT().Enable(true).Return(nil),
+	)
+
+	lp.Update(0, false)
+
+	if lp.dischargeCurrent != 0 || !lp.enabled {
+		t.Errorf("expected discharge stopped and charging enabled, got %.3gA", lp.dischargeCurrent)
+	}
+}
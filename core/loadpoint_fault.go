@@ -0,0 +1,181 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+const (
+	evChargerFault     = "chargerFault"     // charger entered fault status
+	evChargerRecovered = "chargerRecovered" // charger left fault status
+	evChargerEscalated = "chargerEscalated" // charger fault not recovered by recovery actions
+)
+
+// Fault recovery actions
+const (
+	faultCycle    = "cycle"    // disable and re-enable the charger
+	faultWait     = "wait"     // wait for the charger to recover by itself
+	faultEscalate = "escalate" // notify that the fault requires manual intervention
+)
+
+// faultCycleDelay is the time the charger stays disabled while cycling
+const faultCycleDelay = 10 * time.Second
+
+// FaultAction defines a recovery step and the time to wait before the next step
+type FaultAction struct {
+	Action string        `mapstructure:"action"` // cycle, wait, escalate
+	Delay  time.Duration `mapstructure:"delay"`  // wait time before the next action
+}
+
+// FaultConfig defines the recovery actions executed while the charger is faulted
+type FaultConfig struct {
+	Recovery []FaultAction `mapstructure:"recovery"`
+}
+
+// defaultFaultRecovery cycles the charger twice before escalating
+var defaultFaultRecovery = []FaultAction{
+	{Action: faultCycle, Delay: time.Minute},
+	{Action: faultWait, Delay: 10 * time.Minute},
+	{Action: faultCycle, Delay: time.Minute},
+	{Action: faultEscalate},
+}
+
+// configureFault validates the fault recovery actions
+func (lp *LoadPoint) configureFault() error {
+	if lp.Fault.Recovery == nil {
+		lp.Fault.Recovery = defaultFaultRecovery
+	}
+
+	for _, a := range lp.Fault.Recovery {
+		switch a.Action {
+		case faultCycle, faultWait, faultEscalate:
+		default:
+			return fmt.Errorf("invalid fault recovery action: %s", a.Action)
+		}
+	}
+
+	return nil
+}
+
+// faulted returns true if the charger reports an error status
+func (lp *LoadPoint) faulted() bool {
+	status := lp.GetStatus()
+	return status == api.StatusE || status == api.StatusF
+}
+
+// faultDetail returns the charger's fault reason or the fault status
+func (lp *LoadPoint) faultDetail() string {
+	if fr, ok := lp.charger.(api.FaultReporter); ok {
+		detail, err := fr.FaultDetail()
+		if err == nil && detail != "" {
+			return detail
+		}
+
+		if err != nil {
+			lp.log.ERROR.Printf("charger fault detail: %v", err)
+		}
+	}
+
+	return fmt.Sprintf("status %s", lp.GetStatus())
+}
+
+// updateFault tracks charger fault status and runs the recovery actions.
+// It returns true while the charger is faulted and loadpoint control must be paused.
+func (lp *LoadPoint) updateFault() bool {
+	if !lp.faulted() {
+		// charger recovered while disabled by cycling
+		if !lp.faultReenable.IsZero() {
+			lp.reenableCharger()
+		}
+
+		if !lp.faultStart.IsZero() {
+			duration := lp.clock.Since(lp.faultStart)
+			lp.faultDuration += duration
+			lp.faultStart = time.Time{}
+
+			lp.log.INFO.Printf("charger recovered after %v", duration.Round(time.Second))
+			lp.publish("chargerFault", "")
+			lp.publish("chargerFaultDuration", lp.faultDuration)
+			lp.pushEvent(evChargerRecovered)
+		}
+
+		return false
+	}
+
+	if lp.faultStart.IsZero() {
+		lp.faultStart = lp.clock.Now()
+		lp.faultCount++
+		lp.faultStep = 0
+		lp.faultNext = lp.faultStart
+
+		detail := lp.faultDetail()
+		lp.log.ERROR.Printf("charger fault: %s", detail)
+
+		lp.publish("chargerFault", detail)
+		lp.publish("chargerFaultCount", lp.faultCount)
+		lp.pushEvent(evChargerFault)
+	}
+
+	lp.publish("chargerFaultDuration", lp.faultDuration+lp.clock.Since(lp.faultStart))
+
+	// control is paused, don't keep discharging
+	lp.stopDischarge()
+
+	// complete charger cycle before running further actions
+	if !lp.faultReenable.IsZero() {
+		if !lp.clock.Now().Before(lp.faultReenable) {
+			lp.reenableCharger()
+		}
+		return true
+	}
+
+	lp.recoverFault()
+
+	return true
+}
+
+// recoverFault executes the next recovery action once the previous action's delay has passed
+func (lp *LoadPoint) recoverFault() {
+	if lp.faultStep >= len(lp.Fault.Recovery) || lp.clock.Now().Before(lp.faultNext) {
+		return
+	}
+
+	action := lp.Fault.Recovery[lp.faultStep]
+	lp.faultStep++
+	lp.faultNext = lp.clock.Now().Add(action.Delay)
+
+	switch action.Action {
+	case faultCycle:
+		lp.log.INFO.Println("charger fault: cycling charger")
+
+		if err := lp.charger.Enable(false); err != nil {
+			lp.log.ERROR.Printf("charger fault: cycle: %v", err)
+			return
+		}
+
+		// re-enable after the charger had time to reset
+		lp.faultReenable = lp.clock.Now().Add(faultCycleDelay)
+
+	case faultWait:
+		lp.log.INFO.Printf("charger fault: waiting %v for recovery", action.Delay)
+
+	case faultEscalate:
+		lp.log.ERROR.Printf("charger fault: not recovered after %v", lp.clock.Since(lp.faultStart).Round(time.Second))
+		lp.pushEvent(evChargerEscalated)
+	}
+}
+
+// reenableCharger completes a charger cycle by restoring the charger's enabled state
+func (lp *LoadPoint) reenableCharger() {
+	if lp.enabled {
+		if err := lp.charger.Enable(true); err != nil {
+			// retry on next update
+			lp.log.ERROR.Printf("charger fault: cycle: %v", err)
+			return
+		}
+	}
+
+	lp.faultReenable = time.Time{}
+}
@@ -0,0 +1,229 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+)
+
+func TestFaultRecovery(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+	charger := mock.NewMockCharger(ctrl)
+
+	pushChan := make(chan push.Event, 10)
+
+	lp := &LoadPoint{
+		log:         util.NewLogger("foo"),
+		bus:         evbus.New(),
+		pushChan:    pushChan,
+		clock:       clock,
+		charger:     charger,
+		chargeMeter: &Null{}, // silence nil panics
+		chargeRater: &Null{}, // silence nil panics
+		chargeTimer: &Null{}, // silence nil panics
+		MinCurrent:  minA,
+		MaxCurrent:  maxA,
+		status:      api.StatusB,
+		enabled:     true,
+		Mode:        api.ModeNow,
+		Fault: FaultConfig{
+			Recovery: []FaultAction{
+				{Action: faultCycle, Delay: time.Minute},
+				{Action: faultWait, Delay: 5 * time.Minute},
+				{Action: faultEscalate},
+			},
+		},
+	}
+
+	if err := lp.configureFault(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectEvent := func(event string) {
+		t.Helper()
+		select {
+		case ev := <-pushChan:
+			if ev.Event != event {
+				t.Errorf("expected event %s, got %s", event, ev.Event)
+			}
+		default:
+			t.Errorf("expected event %s", event)
+		}
+	}
+
+	expectNoEvent := func() {
+		t.Helper()
+		select {
+		case ev := <-pushChan:
+			t.Errorf("unexpected event %s", ev.Event)
+		default:
+		}
+	}
+
+	// fault detected, charger disabled, no current limit applied
+	charger.EXPECT().Status().Return(api.StatusF, nil)
+	charger.EXPECT().Enable(false).Return(nil)
+	lp.Update(0, false)
+	ctrl.Finish()
+	expectEvent(evChargerFault)
+
+	if lp.faultCount != 1 {
+		t.Errorf("fault count: expected 1, got %d", lp.faultCount)
+	}
+
+	// charger stays disabled during cycle delay
+	clock.Add(faultCycleDelay / 2)
+	charger.EXPECT().Status().Return(api.StatusF, nil)
+	lp.Update(0, false)
+	ctrl.Finish()
+
+	// charger re-enabled after cycle delay
+	clock.Add(faultCycleDelay / 2)
+	charger.EXPECT().Status().Return(api.StatusF, nil)
+	charger.EXPECT().Enable(true).Return(nil)
+	lp.Update(0, false)
+	ctrl.Finish()
+
+	// waiting
+	for i := 0; i < 2; i++ {
+		clock.Add(time.Minute)
+		charger.EXPECT().Status().Return(api.StatusF, nil)
+		lp.Update(0, false)
+		ctrl.Finish()
+		expectNoEvent()
+	}
+
+	// escalated after wait delay
+	clock.Add(5 * time.Minute)
+	charger.EXPECT().Status().Return(api.StatusF, nil)
+	lp.Update(0, false)
+	ctrl.Finish()
+	expectEvent(evChargerEscalated)
+
+	// no further actions, pending tasks are still run
+	var task bool
+	lp.task(func() error {
+		task = true
+		return nil
+	})
+
+	clock.Add(time.Hour)
+	charger.EXPECT().Status().Return(api.StatusF, nil)
+	lp.Update(0, false)
+	ctrl.Finish()
+	expectNoEvent()
+
+	if !task {
+		t.Error("task not run during fault")
+	}
+
+	// recovered, control resumes
+	clock.Add(time.Minute)
+	charger.EXPECT().Enabled().Return(true, nil)
+	charger.EXPECT().Status().Return(api.StatusB, nil)
+	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
+	lp.Update(0, false)
+	ctrl.Finish()
+	expectEvent(evChargerRecovered)
+
+	if d := 68*time.Minute + faultCycleDelay; lp.faultDuration != d {
+		t.Errorf("fault duration: expected %v, got %v", d, lp.faultDuration)
+	}
+}
+
+type faultCharger struct {
+	*mock.MockCharger
+	detail string
+}
+
+func (c *faultCharger) FaultDetail() (string, error) {
+	return c.detail, nil
+}
+
+func TestFaultDetail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := &LoadPoint{
+		log:     util.NewLogger("foo"),
+		charger: mock.NewMockCharger(ctrl),
+		status:  api.StatusE,
+	}
+
+	if d := lp.faultDetail(); d != "status E" {
+		t.Errorf("unexpected detail: %s", d)
+	}
+
+	lp.charger = &faultCharger{MockCharger: mock.NewMockCharger(ctrl), detail: "rcd tripped"}
+
+	if d := lp.faultDetail(); d != "rcd tripped" {
+		t.Errorf("unexpected detail: %s", d)
+	}
+}
+
+func TestFaultConfig(t *testing.T) {
+	lp := &LoadPoint{}
+	if err := lp.configureFault(); err != nil || len(lp.Fault.Recovery) != len(defaultFaultRecovery) {
+		t.Errorf("expected default recovery, got %v %v", lp.Fault.Recovery, err)
+	}
+
+	lp.Fault.Recovery = []FaultAction{{Action: "reboot"}}
+	if err := lp.configureFault(); err == nil {
+		t.Error("expected invalid action error")
+	}
+}
+
+func TestFaultCycleDischarge(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	charger := &struct {
+		*mock.MockCharger
+		*mock.MockChargerDischarge
+	}{
+		mock.NewMockCharger(ctrl),
+		mock.NewMockChargerDischarge(ctrl),
+	}
+
+	lp := &LoadPoint{
+		log:              util.NewLogger("foo"),
+		pushChan:         make(chan push.Event, 10),
+		clock:            clock,
+		charger:          charger,
+		status:           api.StatusF,
+		enabled:          true,
+		dischargeCurrent: minA,
+		Fault: FaultConfig{
+			Recovery: []FaultAction{{Action: faultCycle, Delay: time.Minute}},
+		},
+	}
+
+	// discharge stopped before cycling
+	gomock.InOrder(
+		charger.MockChargerDischarge.EXPECT().MaxDischargeCurrent(0.0).Return(nil),
+		charger.MockCharger.EXPECT().Enable(false).Return(nil),
+	)
+	if !lp.updateFault() {
+		t.Error("expected control paused")
+	}
+	ctrl.Finish()
+
+	// recovered before cycle delay, charger re-enabled
+	lp.status = api.StatusB
+	charger.MockCharger.EXPECT().Enable(true).Return(nil)
+	if lp.updateFault() {
+		t.Error("expected control resumed")
+	}
+	ctrl.Finish()
+
+	if !lp.faultReenable.IsZero() {
+		t.Error("unexpected pending re-enable")
+	}
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"errors"
+	"math"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/session"
+)
+
+// vehicleState reads soc and odometer from the vehicle. Values are 0 if not available.
+func (lp *LoadPoint) vehicleState(vehicle api.Vehicle) (soc, odometer float64, err error) {
+	soc, err = vehicle.SoC()
+	if errors.Is(err, api.ErrMustRetry) {
+		return 0, 0, err
+	}
+	if err != nil {
+		lp.log.ERROR.Printf("session soc: %v", err)
+		soc = 0
+	}
+
+	if vo, ok := vehicle.(api.VehicleOdometer); ok {
+		odometer, err = vo.Odometer()
+		if errors.Is(err, api.ErrMustRetry) {
+			return 0, 0, err
+		}
+		if err != nil {
+			if !errors.Is(err, api.ErrNotAvailable) {
+				lp.log.ERROR.Printf("session odometer: %v", err)
+			}
+			odometer = 0
+		}
+	}
+
+	return soc, odometer, nil
+}
+
+// startSession creates a new session on vehicle connect
+func (lp *LoadPoint) startSession() {
+	lp.session = &session.Session{
+		Loadpoint: lp.Title,
+		Connected: lp.clock.Now(),
+	}
+
+	lp.publish("tripDistance", 0.0)
+	lp.publish("tripConsumption", 0.0)
+
+	if lp.vehicle != nil {
+		lp.task(lp.sessionVehicle)
+	}
+}
+
+// sessionVehicle records soc and odometer of the session's vehicle at connect
+// and calculates distance and consumption since the vehicle's previous session.
+// Energy charged before the soc could be read is deducted from the start soc.
+func (lp *LoadPoint) sessionVehicle() error {
+	s, vehicle := lp.session, lp.vehicle
+	if s == nil || vehicle == nil {
+		return nil
+	}
+
+	soc, odometer, err := lp.vehicleState(vehicle)
+	if err != nil {
+		return err
+	}
+
+	// vehicle identified or woken up after charging has started
+	if energy, capacity := (lp.chargedEnergy-lp.dischargedEnergy)/1e3, float64(vehicle.Capacity()); soc > 0 && energy > 0 && capacity > 0 {
+		soc = math.Max(soc-100*energy/capacity, 0)
+	}
+
+	if odometer > 0 {
+		lp.vehicleOdometer = odometer
+	}
+
+	s.Vehicle = vehicle.Title()
+	s.SoCStart = soc
+	s.OdometerStart = odometer
+
+	s.Trip(session.Last(s.Vehicle), float64(vehicle.Capacity()))
+
+	if s.Distance > 0 {
+		lp.log.INFO.Printf("trip: %.0fkm, %.1fkWh/100km", s.Distance, s.Consumption)
+		lp.publish("tripDistance", s.Distance)
+		lp.publish("tripConsumption", s.Consumption)
+	}
+
+	return nil
+}
+
+// stopSession completes the session on vehicle disconnect
+func (lp *LoadPoint) stopSession() {
+	s := lp.session
+	if s == nil {
+		return
+	}
+
+	lp.session = nil
+
+	s.Disconnected = lp.clock.Now()
+	s.ChargedEnergy = lp.chargedEnergy / 1e3
+	s.DischargedEnergy = lp.dischargedEnergy / 1e3
+
+	if vehicle := lp.vehicle; vehicle != nil {
+		if s.Vehicle == "" {
+			s.Vehicle = vehicle.Title()
+		}
+
+		// last known values, the vehicle may already be asleep
+		s.SoCEnd = lp.vehicleSoc
+		s.OdometerEnd = lp.vehicleOdometer
+	}
+
+	if err := session.Add(*s); err != nil {
+		lp.log.ERROR.Printf("session: %v", err)
+	}
+}
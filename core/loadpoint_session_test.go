@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+)
+
+func TestSession(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	vehicle := mock.NewMockVehicle(ctrl)
+	vehicle.EXPECT().Title().Return("session").AnyTimes()
+	vehicle.EXPECT().Capacity().Return(int64(50)).AnyTimes()
+
+	lp := &LoadPoint{
+		log:     util.NewLogger("foo"),
+		clock:   clock,
+		vehicle: vehicle,
+	}
+
+	lp.startSession()
+
+	// vehicle asleep
+	vehicle.EXPECT().SoC().Return(0.0, api.ErrMustRetry)
+	lp.runTasks()
+	ctrl.Finish()
+
+	// energy charged before the soc could be read
+	lp.chargedEnergy = 5e3
+	vehicle.EXPECT().SoC().Return(40.0, nil)
+	lp.runTasks()
+	ctrl.Finish()
+
+	// last known soc is recorded without querying the vehicle
+	lp.chargedEnergy = 10e3
+	lp.vehicleSoc = 50
+	lp.stopSession()
+	ctrl.Finish()
+
+	s := session.Last("session")
+	if s == nil {
+		t.Fatal("missing session")
+	}
+
+	if s.SoCStart != 30 || s.SoCEnd != 50 || s.ChargedEnergy != 10 {
+		t.Errorf("unexpected session: %+v", s)
+	}
+}
@@ -11,16 +11,17 @@ func (lp *LoadPoint) task(task func() error) {
 	lp.tasks = append(lp.tasks, task)
 }
 
-// runTasks runs all defined tasks
+// runTasks runs all defined tasks, tasks added while running are kept for the next run
 func (lp *LoadPoint) runTasks() {
-	var incomplete []func() error
-	for _, task := range lp.tasks {
+	tasks := lp.tasks
+	lp.tasks = nil
+
+	for _, task := range tasks {
 		err := task()
 		if errors.Is(err, api.ErrMustRetry) {
-			incomplete = append(incomplete, task)
+			lp.task(task)
 		}
 	}
-	lp.tasks = incomplete
 }
 
 func (lp *LoadPoint) odometer() error {
@@ -32,6 +33,7 @@ func (lp *LoadPoint) odometer() error {
 	odo, err := v.Odometer()
 	switch err {
 	case nil:
+		lp.vehicleOdometer = odo
 		lp.publish("vehicleOdometer", odo)
 	case api.ErrMustRetry:
 	default:
@@ -0,0 +1,210 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+const (
+	vehicleSyncRetry      = 5 * time.Minute  // retry interval for failed target soc updates
+	vehicleWakeupTimeout  = 30 * time.Second // wait for charging to start before waking up the vehicle
+	vehicleWakeupAttempts = 3
+	vehicleCommandTimeout = 2 * time.Minute // give up commands if the vehicle does not wake up
+)
+
+// vehicleCommand sends a vehicle command in the background instead of blocking the loadpoint
+// while waiting for the vehicle. The result is handled by a loadpoint task on the following updates.
+// Commands returning api.ErrMustRetry, e.g. while the vehicle is waking up, are repeated until they
+// time out or valid returns false. Final errors are logged and the result is passed to the optional
+// done callback.
+func (lp *LoadPoint) vehicleCommand(name string, valid func() bool, cmd func() error, done func(error)) {
+	deadline := lp.clock.Now().Add(vehicleCommandTimeout)
+	res := make(chan error, 1)
+
+	send := func() {
+		lp.commands.Add(1)
+		go func() {
+			defer lp.commands.Done()
+			res <- cmd()
+		}()
+	}
+
+	send()
+
+	lp.task(func() error {
+		var err error
+		select {
+		case err = <-res:
+		default:
+			// command still running
+			return api.ErrMustRetry
+		}
+
+		if errors.Is(err, api.ErrMustRetry) {
+			if !valid() {
+				return nil
+			}
+
+			if lp.clock.Now().Before(deadline) {
+				send()
+				return err
+			}
+
+			err = api.ErrTimeout
+		}
+
+		if err != nil {
+			lp.log.ERROR.Printf("vehicle %s: %v", name, err)
+		}
+
+		if done != nil {
+			done(err)
+		}
+
+		return nil
+	})
+}
+
+// syncVehicleTargetSoC raises the vehicle's own charge limit if it is below the target soc.
+// The vehicle would otherwise stop charging early.
+func (lp *LoadPoint) syncVehicleTargetSoC() {
+	vl, ok := lp.vehicle.(api.VehicleChargeLimiter)
+	if !ok || !lp.connected() {
+		return
+	}
+
+	target := lp.GetTargetSoC()
+	if target == lp.vehicleTargetSoC ||
+		target == lp.vehicleTargetSoCFailed && lp.clock.Since(lp.vehicleTargetSoCUpdated) < vehicleSyncRetry {
+		return
+	}
+
+	lp.vehicleTargetSoCUpdated = lp.clock.Now()
+
+	limit, err := vl.TargetSoC()
+	if err != nil {
+		if !errors.Is(err, api.ErrMustRetry) {
+			lp.log.ERROR.Printf("vehicle target soc: %v", err)
+		}
+		lp.vehicleTargetSoCFailed = target
+		return
+	}
+
+	// considered synced while the update is pending, reset if the update fails
+	lp.vehicleTargetSoC = target
+	lp.vehicleTargetSoCFailed = 0
+
+	if limit >= target {
+		lp.log.DEBUG.Printf("vehicle target soc: %d%% (limit %d%%)", target, limit)
+		return
+	}
+
+	lp.log.DEBUG.Printf("vehicle target soc: %d%% (was %d%%)", target, limit)
+
+	vehicle := lp.vehicle
+	valid := func() bool {
+		return lp.connected() && lp.vehicle == vehicle && lp.vehicleTargetSoC == target
+	}
+
+	lp.vehicleCommand("target soc", valid, func() error {
+		return vl.SetTargetSoC(target)
+	}, func(err error) {
+		if err != nil && valid() {
+			lp.vehicleTargetSoC = 0
+			lp.vehicleTargetSoCFailed = target
+		}
+	})
+}
+
+// resetVehicleSync forces vehicle settings to be synced again, e.g. after the vehicle has changed
+func (lp *LoadPoint) resetVehicleSync() {
+	lp.vehicleTargetSoC = 0
+	lp.vehicleTargetSoCFailed = 0
+	lp.vehicleStopped = false
+}
+
+// vehicleChargeRequired checks if the vehicle soc is known and below the target soc
+func (lp *LoadPoint) vehicleChargeRequired() bool {
+	return lp.vehicleSoc > 0 && lp.vehicleSoc < float64(lp.GetTargetSoC())
+}
+
+// stopVehicle sends the charge stop command to vehicles after the charger has been disabled.
+// Only vehicles that can be started again by startVehicle are stopped.
+func (lp *LoadPoint) stopVehicle() {
+	vs, ok := lp.vehicle.(api.VehicleStopCharge)
+	if _, ok2 := lp.vehicle.(api.VehicleStartCharge); !ok || !ok2 {
+		return
+	}
+
+	vehicle := lp.vehicle
+	valid := func() bool {
+		return !lp.enabled && lp.vehicle == vehicle
+	}
+
+	lp.vehicleCommand("charge stop", valid, vs.StopCharge, func(err error) {
+		if err == nil && lp.vehicle == vehicle {
+			lp.vehicleStopped = true
+
+			// charger has been enabled while stopping
+			if lp.enabled {
+				lp.startVehicle()
+			}
+		}
+	})
+}
+
+// startVehicle sends the charge start command after the charger has been enabled
+// if charging has been stopped by stopVehicle, independent of the vehicle's soc.
+func (lp *LoadPoint) startVehicle() {
+	vs, ok := lp.vehicle.(api.VehicleStartCharge)
+	if !ok || !lp.vehicleStopped {
+		return
+	}
+
+	vehicle := lp.vehicle
+	valid := func() bool {
+		return lp.enabled && lp.vehicle == vehicle && lp.vehicleStopped
+	}
+
+	lp.vehicleCommand("charge start", valid, vs.StartCharge, func(err error) {
+		if err == nil && lp.vehicle == vehicle {
+			lp.vehicleStopped = false
+		}
+	})
+}
+
+// wakeUpVehicle sends charge start commands to vehicles that did not start charging
+// after the charger has been enabled, e.g. because they went to sleep while connected.
+// Vehicles are only woken up if their soc is known to be below the target soc.
+func (lp *LoadPoint) wakeUpVehicle() {
+	vs, ok := lp.vehicle.(api.VehicleStartCharge)
+	if !ok || !lp.enabled || lp.GetStatus() != api.StatusB || !lp.vehicleChargeRequired() {
+		lp.wakeupAttempts = 0
+		lp.wakeupTimer = time.Time{}
+		return
+	}
+
+	// start waiting for charging
+	if lp.wakeupTimer.IsZero() {
+		lp.wakeupTimer = lp.clock.Now()
+		return
+	}
+
+	if lp.wakeupAttempts >= vehicleWakeupAttempts || lp.clock.Since(lp.wakeupTimer) < vehicleWakeupTimeout {
+		return
+	}
+
+	lp.wakeupAttempts++
+	lp.wakeupTimer = lp.clock.Now()
+
+	lp.log.DEBUG.Printf("vehicle wake-up (attempt %d/%d)", lp.wakeupAttempts, vehicleWakeupAttempts)
+
+	vehicle := lp.vehicle
+	valid := func() bool {
+		return lp.enabled && lp.GetStatus() == api.StatusB && lp.vehicle == vehicle
+	}
+
+	lp.vehicleCommand("wake-up", valid, vs.StartCharge, nil)
+}
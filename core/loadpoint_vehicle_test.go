@@ -0,0 +1,296 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/golang/mock/gomock"
+)
+
+func TestSyncVehicleTargetSoC(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	vehicle := struct {
+		*mock.MockVehicle
+		*mock.MockVehicleChargeLimiter
+	}{
+		mock.NewMockVehicle(ctrl),
+		mock.NewMockVehicleChargeLimiter(ctrl),
+	}
+
+	lp := &LoadPoint{
+		log:     util.NewLogger("foo"),
+		clock:   clock,
+		vehicle: vehicle,
+		status:  api.StatusB,
+		SoC: SoCConfig{
+			Target: 90,
+		},
+	}
+
+	// initial sync
+	vehicle.MockVehicleChargeLimiter.EXPECT().TargetSoC().Return(80, nil)
+	vehicle.MockVehicleChargeLimiter.EXPECT().SetTargetSoC(90).Return(nil)
+	lp.syncVehicleTargetSoC()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	// no change
+	lp.syncVehicleTargetSoC()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	// vehicle limit above target
+	lp.SoC.Target = 70
+	vehicle.MockVehicleChargeLimiter.EXPECT().TargetSoC().Return(90, nil)
+	lp.syncVehicleTargetSoC()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	// failed update is retried after interval
+	lp.SoC.Target = 95
+	vehicle.MockVehicleChargeLimiter.EXPECT().TargetSoC().Return(90, nil)
+	vehicle.MockVehicleChargeLimiter.EXPECT().SetTargetSoC(95).Return(errors.New("foo"))
+	lp.syncVehicleTargetSoC()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	lp.syncVehicleTargetSoC()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	clock.Add(vehicleSyncRetry)
+	vehicle.MockVehicleChargeLimiter.EXPECT().TargetSoC().Return(90, nil)
+	vehicle.MockVehicleChargeLimiter.EXPECT().SetTargetSoC(95).Return(nil)
+	lp.syncVehicleTargetSoC()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	// update is repeated while the vehicle wakes up
+	lp.resetVehicleSync()
+	vehicle.MockVehicleChargeLimiter.EXPECT().TargetSoC().Return(90, nil)
+	vehicle.MockVehicleChargeLimiter.EXPECT().SetTargetSoC(95).Return(api.ErrMustRetry)
+	vehicle.MockVehicleChargeLimiter.EXPECT().SetTargetSoC(95).Return(nil)
+	lp.syncVehicleTargetSoC()
+	waitTasks(lp)
+	waitTasks(lp)
+	ctrl.Finish()
+
+	// not synced while disconnected, pending retry is dropped
+	lp.resetVehicleSync()
+	vehicle.MockVehicleChargeLimiter.EXPECT().TargetSoC().Return(90, nil)
+	vehicle.MockVehicleChargeLimiter.EXPECT().SetTargetSoC(95).Return(api.ErrMustRetry)
+	lp.syncVehicleTargetSoC()
+	lp.status = api.StatusA
+	waitTasks(lp)
+	lp.syncVehicleTargetSoC()
+	ctrl.Finish()
+
+	if len(lp.tasks) > 0 {
+		t.Error("pending tasks")
+	}
+
+	// re-synced after reset
+	lp.resetVehicleSync()
+	lp.status = api.StatusB
+	vehicle.MockVehicleChargeLimiter.EXPECT().TargetSoC().Return(90, nil)
+	vehicle.MockVehicleChargeLimiter.EXPECT().SetTargetSoC(95).Return(nil)
+	lp.syncVehicleTargetSoC()
+	waitTasks(lp)
+	ctrl.Finish()
+}
+
+func TestWakeUpVehicle(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	vehicle := struct {
+		*mock.MockVehicle
+		*mock.MockVehicleStartCharge
+	}{
+		mock.NewMockVehicle(ctrl),
+		mock.NewMockVehicleStartCharge(ctrl),
+	}
+
+	lp := &LoadPoint{
+		log:        util.NewLogger("foo"),
+		clock:      clock,
+		vehicle:    vehicle,
+		status:     api.StatusB,
+		enabled:    true,
+		vehicleSoc: 50,
+		SoC: SoCConfig{
+			Target: 80,
+		},
+	}
+
+	// start waiting
+	lp.wakeUpVehicle()
+	clock.Add(vehicleWakeupTimeout - time.Second)
+	lp.wakeUpVehicle()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	// limited number of attempts
+	vehicle.MockVehicleStartCharge.EXPECT().StartCharge().Return(nil).Times(vehicleWakeupAttempts)
+	for i := 0; i <= vehicleWakeupAttempts; i++ {
+		clock.Add(vehicleWakeupTimeout)
+		lp.wakeUpVehicle()
+		waitTasks(lp)
+	}
+	ctrl.Finish()
+
+	// charging resets attempts
+	lp.status = api.StatusC
+	lp.wakeUpVehicle()
+
+	lp.status = api.StatusB
+	lp.wakeUpVehicle()
+	clock.Add(vehicleWakeupTimeout)
+	vehicle.MockVehicleStartCharge.EXPECT().StartCharge().Return(api.ErrMustRetry)
+	lp.wakeUpVehicle()
+	lp.commands.Wait()
+	ctrl.Finish()
+
+	// command is repeated until timeout
+	clock.Add(vehicleCommandTimeout / 2)
+	vehicle.MockVehicleStartCharge.EXPECT().StartCharge().Return(api.ErrMustRetry)
+	waitTasks(lp)
+	clock.Add(vehicleCommandTimeout)
+	waitTasks(lp)
+	waitTasks(lp)
+	ctrl.Finish()
+
+	if len(lp.tasks) > 0 {
+		t.Error("pending tasks")
+	}
+
+	// no wake-up when target soc is reached or soc is unknown
+	for _, soc := range []float64{80, 0} {
+		lp.vehicleSoc = soc
+		lp.wakeUpVehicle()
+		clock.Add(vehicleWakeupTimeout)
+		lp.wakeUpVehicle()
+		waitTasks(lp)
+		ctrl.Finish()
+	}
+
+	// no wake-up when disabled
+	lp.vehicleSoc = 50
+	lp.enabled = false
+	clock.Add(vehicleWakeupTimeout)
+	lp.wakeUpVehicle()
+	waitTasks(lp)
+	ctrl.Finish()
+}
+
+func TestStopStartVehicle(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	vehicle := struct {
+		*mock.MockVehicle
+		*mock.MockVehicleStartCharge
+		*mock.MockVehicleStopCharge
+	}{
+		mock.NewMockVehicle(ctrl),
+		mock.NewMockVehicleStartCharge(ctrl),
+		mock.NewMockVehicleStopCharge(ctrl),
+	}
+
+	lp := &LoadPoint{
+		log:     util.NewLogger("foo"),
+		clock:   clock,
+		vehicle: vehicle,
+	}
+
+	// not started if not stopped before
+	lp.enabled = true
+	lp.startVehicle()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	lp.enabled = false
+	vehicle.MockVehicleStopCharge.EXPECT().StopCharge().Return(nil)
+	lp.stopVehicle()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	if !lp.vehicleStopped {
+		t.Error("vehicle not stopped")
+	}
+
+	// started although soc is unknown
+	lp.enabled = true
+	vehicle.MockVehicleStartCharge.EXPECT().StartCharge().Return(nil)
+	lp.startVehicle()
+	waitTasks(lp)
+	ctrl.Finish()
+
+	if lp.vehicleStopped {
+		t.Error("vehicle still stopped")
+	}
+
+	// started if the charger has been enabled while stopping
+	lp.enabled = false
+	vehicle.MockVehicleStopCharge.EXPECT().StopCharge().Return(nil)
+	vehicle.MockVehicleStartCharge.EXPECT().StartCharge().Return(nil)
+	lp.stopVehicle()
+	lp.enabled = true
+	lp.startVehicle()
+	waitTasks(lp)
+	waitTasks(lp)
+	ctrl.Finish()
+
+	if lp.vehicleStopped {
+		t.Error("vehicle still stopped")
+	}
+
+	// retry dropped once the charger has been enabled again
+	lp.enabled = false
+	vehicle.MockVehicleStopCharge.EXPECT().StopCharge().Return(api.ErrMustRetry)
+	lp.stopVehicle()
+	lp.enabled = true
+	waitTasks(lp)
+	ctrl.Finish()
+
+	if lp.vehicleStopped || len(lp.tasks) > 0 {
+		t.Error("vehicle stopped")
+	}
+}
+
+func TestStopVehicleWithoutStart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	vehicle := struct {
+		*mock.MockVehicle
+		*mock.MockVehicleStopCharge
+	}{
+		mock.NewMockVehicle(ctrl),
+		mock.NewMockVehicleStopCharge(ctrl),
+	}
+
+	lp := &LoadPoint{
+		log:     util.NewLogger("foo"),
+		clock:   clock.NewMock(),
+		vehicle: vehicle,
+	}
+
+	// vehicles that cannot be started again are not stopped
+	lp.stopVehicle()
+	waitTasks(lp)
+	ctrl.Finish()
+}
+
+// waitTasks runs the loadpoint tasks after pending vehicle commands have completed
+func waitTasks(lp *LoadPoint) {
+	lp.commands.Wait()
+	lp.runTasks()
+	lp.commands.Wait()
+}
@@ -0,0 +1,127 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Session is a vehicle charging session from connect to disconnect
+type Session struct {
+	Loadpoint        string    `json:"loadpoint"`
+	Vehicle          string    `json:"vehicle"`
+	Connected        time.Time `json:"connected"`
+	Disconnected     time.Time `json:"disconnected"`
+	ChargedEnergy    float64   `json:"chargedEnergy"`    // kWh
+	DischargedEnergy float64   `json:"dischargedEnergy"` // kWh fed back from the vehicle
+	SoCStart         float64   `json:"socStart"`         // %, 0 if unknown
+	SoCEnd           float64   `json:"socEnd"`           // %, 0 if unknown
+	OdometerStart    float64   `json:"odometerStart"`    // km, 0 if unknown
+	OdometerEnd      float64   `json:"odometerEnd"`      // km, 0 if unknown
+	Distance         float64   `json:"distance"`         // km driven since previous session, 0 if unknown
+	Consumption      float64   `json:"consumption"`      // kWh/100km since previous session, 0 if unknown
+}
+
+// Trip calculates distance and consumption driven since the previous session of the same vehicle
+func (s *Session) Trip(prev *Session, capacity float64) {
+	if prev == nil || prev.OdometerEnd == 0 || s.OdometerStart <= prev.OdometerEnd {
+		return
+	}
+
+	s.Distance = s.OdometerStart - prev.OdometerEnd
+
+	if prev.SoCEnd > 0 && s.SoCStart > 0 && s.SoCStart < prev.SoCEnd && capacity > 0 {
+		energy := (prev.SoCEnd - s.SoCStart) / 100 * capacity
+		s.Consumption = 100 * energy / s.Distance
+	}
+}
+
+// store persists the completed sessions
+type store struct {
+	mu       sync.Mutex
+	file     string
+	sessions []Session
+}
+
+var sessions = new(store)
+
+// Configure loads the completed sessions from file.
+// Sessions are only kept in memory if not configured.
+func Configure(file string) error {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+
+	sessions.file = file
+
+	b, err := ioutil.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err == nil {
+		err = json.Unmarshal(b, &sessions.sessions)
+	}
+
+	return err
+}
+
+// Add persists a completed session
+func Add(s Session) error {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+
+	sessions.sessions = append(sessions.sessions, s)
+
+	if sessions.file == "" {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(sessions.sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(sessions.file), 0755); err != nil {
+		return err
+	}
+
+	tmp := sessions.file + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, sessions.file)
+}
+
+// All returns the completed sessions, optionally filtered by vehicle
+func All(vehicle string) []Session {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+
+	res := make([]Session, 0, len(sessions.sessions))
+	for _, s := range sessions.sessions {
+		if vehicle == "" || s.Vehicle == vehicle {
+			res = append(res, s)
+		}
+	}
+
+	return res
+}
+
+// Last returns the last completed session of the vehicle
+func Last(vehicle string) *Session {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+
+	for i := len(sessions.sessions) - 1; i >= 0; i-- {
+		if s := sessions.sessions[i]; s.Vehicle == vehicle {
+			return &s
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package session
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestTrip(t *testing.T) {
+	tc := []struct {
+		prev                  *Session
+		odometer, soc         float64
+		distance, consumption float64
+	}{
+		{nil, 1100, 40, 0, 0},
+		{&Session{OdometerEnd: 0, SoCEnd: 80}, 1100, 40, 0, 0},
+		{&Session{OdometerEnd: 1000, SoCEnd: 80}, 1000, 40, 0, 0},
+		{&Session{OdometerEnd: 1000, SoCEnd: 0}, 1200, 40, 200, 0},
+		{&Session{OdometerEnd: 1000, SoCEnd: 80}, 1200, 40, 200, 10},
+		{&Session{OdometerEnd: 1000, SoCEnd: 40}, 1200, 50, 200, 0},
+	}
+
+	for _, tc := range tc {
+		s := &Session{OdometerStart: tc.odometer, SoCStart: tc.soc}
+		s.Trip(tc.prev, 50)
+
+		if s.Distance != tc.distance || s.Consumption != tc.consumption {
+			t.Errorf("%+v: expected %.0fkm %.1fkWh/100km, got %.0fkm %.1fkWh/100km", tc.prev, tc.distance, tc.consumption, s.Distance, s.Consumption)
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	defer func() { sessions = new(store) }()
+
+	file := filepath.Join(t.TempDir(), "sessions.json")
+	if err := Configure(file); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []Session{
+		{Vehicle: "foo", OdometerEnd: 100},
+		{Vehicle: "bar", OdometerEnd: 200},
+		{Vehicle: "foo", OdometerEnd: 300},
+	} {
+		if err := Add(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// reload from file
+	sessions = new(store)
+	if err := Configure(file); err != nil {
+		t.Fatal(err)
+	}
+
+	if res := All(""); len(res) != 3 {
+		t.Errorf("expected 3 sessions, got %d", len(res))
+	}
+
+	if res := All("foo"); len(res) != 2 {
+		t.Errorf("expected 2 sessions, got %d", len(res))
+	}
+
+	if s := Last("foo"); s == nil || s.OdometerEnd != 300 {
+		t.Errorf("unexpected last session: %+v", s)
+	}
+
+	if s := Last("baz"); s != nil {
+		t.Errorf("unexpected last session: %+v", s)
+	}
+}
@@ -37,6 +37,7 @@ type Site struct {
 	ResidualPower float64      `mapstructure:"residualPower"` // PV meter only: household usage. Grid meter: household safety margin
 	Meters        MetersConfig // Meter references
 	PrioritySoC   float64      `mapstructure:"prioritySoC"` // prefer battery up to this SoC
+	Home          *Geofence    `mapstructure:"home"`        // Home area for identifying vehicles
 
 	// meters
 	gridMeter    api.Meter // Grid usage meter
@@ -76,6 +77,16 @@ func NewSiteFromConfig(
 	site.tariff = tariff
 	site.loadpoints = loadpoints
 
+	if site.Home != nil {
+		if site.Home.Radius == 0 {
+			site.Home.Radius = 500 // m
+		}
+
+		for _, lp := range loadpoints {
+			lp.home = site.Home
+		}
+	}
+
 	if site.Meters.GridMeterRef != "" {
 		site.gridMeter = cp.Meter(site.Meters.GridMeterRef)
 	}
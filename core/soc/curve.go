@@ -0,0 +1,113 @@
+package soc
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	curveBins     = 20  // 5% soc resolution
+	curveWeight   = 0.2 // moving average weight of new samples
+	limitedRatio  = 0.9 // charge power below this share of the offered power is limited by the vehicle
+	minSessionSoC = 10  // minimum soc increase of sessions used for learning the efficiency
+)
+
+// Curve is the learned charge curve of a vehicle. It contains the charge power
+// accepted by the vehicle depending on soc and the charge efficiency.
+type Curve struct {
+	Power      []float64 `json:"power"`      // charge power in W accepted per soc bin, 0 if unknown
+	Efficiency float64   `json:"efficiency"` // charge efficiency, 0 if unknown
+}
+
+// bin returns the curve index for the given soc
+func (c *Curve) bin(soc float64) int {
+	i := int(soc * curveBins / 100)
+	if i < 0 {
+		return 0
+	}
+	if i >= curveBins {
+		return curveBins - 1
+	}
+	return i
+}
+
+// Sample adds a charge power sample at soc. The offered power is the power
+// available from the charger. It returns true if the curve has been updated.
+func (c *Curve) Sample(soc, power, offered float64) bool {
+	if power <= 0 || offered <= 0 {
+		return false
+	}
+
+	if len(c.Power) != curveBins {
+		c.Power = make([]float64, curveBins)
+	}
+
+	i := c.bin(soc)
+	cur := c.Power[i]
+
+	switch {
+	// vehicle limits the charge power
+	case power < limitedRatio*offered:
+		if cur == 0 {
+			c.Power[i] = power
+		} else {
+			c.Power[i] = cur + curveWeight*(power-cur)
+		}
+
+	// charger limits the charge power, vehicle accepts at least this power
+	case power > cur:
+		c.Power[i] = power
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+// Session adds a completed charging session with the given soc increase,
+// vehicle capacity and charged energy in Wh. It returns true if the efficiency has been updated.
+func (c *Curve) Session(socDelta, capacity, energy float64) bool {
+	if socDelta < minSessionSoC || capacity <= 0 || energy <= 0 {
+		return false
+	}
+
+	// ignore implausible sessions
+	eff := socDelta / 100 * capacity / energy
+	if eff < 0.5 || eff > 1 {
+		return false
+	}
+
+	if c.Efficiency == 0 {
+		c.Efficiency = eff
+	} else {
+		c.Efficiency += curveWeight * (eff - c.Efficiency)
+	}
+
+	return true
+}
+
+// power returns the expected charge power at soc, limited by the available power
+func (c *Curve) power(soc, available float64) float64 {
+	if len(c.Power) == curveBins {
+		if p := c.Power[c.bin(soc)]; p > 0 && p < available {
+			return p
+		}
+	}
+
+	return available
+}
+
+// Duration estimates the charge duration from soc to target soc given the available
+// power and energy per soc percent in Wh
+func (c *Curve) Duration(soc, target, power, energyPerSoc float64) time.Duration {
+	var hours float64
+
+	for soc < target {
+		step := math.Min(math.Floor(soc)+1, target) - soc
+		hours += step * energyPerSoc / c.power(soc, power)
+		soc += step
+	}
+
+	return time.Duration(float64(time.Hour) * hours).Round(time.Second)
+}
@@ -0,0 +1,99 @@
+package soc
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCurveSample(t *testing.T) {
+	c := new(Curve)
+
+	// charger limited: lower bound of accepted power
+	if !c.Sample(50, 11000, 11000) || c.Power[10] != 11000 {
+		t.Errorf("expected 11000W, got %v", c.Power)
+	}
+
+	// lower charger limited power is ignored
+	if c.Sample(50, 4000, 4100) || c.Power[10] != 11000 {
+		t.Errorf("expected 11000W, got %v", c.Power)
+	}
+
+	// vehicle limited power is averaged
+	c.Sample(90, 3000, 11000)
+	c.Sample(90, 4000, 11000)
+	if exp := 3200.0; c.Power[18] != exp {
+		t.Errorf("expected %.0fW, got %.0fW", exp, c.Power[18])
+	}
+
+	// 100% uses last bin
+	c.Sample(100, 1000, 11000)
+	if c.Power[19] != 1000 {
+		t.Errorf("expected 1000W, got %v", c.Power)
+	}
+}
+
+func TestCurveDuration(t *testing.T) {
+	c := new(Curve)
+
+	// no curve: constant power
+	if d := c.Duration(20, 80, 1000, 100); d != 6*time.Hour {
+		t.Errorf("expected 6h, got %v", d)
+	}
+
+	// tapering above 80%
+	for soc := 80.0; soc < 100; soc += 5 {
+		c.Sample(soc, 500, 1000)
+	}
+
+	if d := c.Duration(70, 90, 1000, 100); d != 3*time.Hour {
+		t.Errorf("expected 3h, got %v", d)
+	}
+
+	// fractional soc
+	if d := c.Duration(79.5, 80.5, 1000, 100); d != 9*time.Minute {
+		t.Errorf("expected 9m, got %v", d)
+	}
+}
+
+func TestCurveSession(t *testing.T) {
+	c := new(Curve)
+
+	if c.Session(5, 10000, 600) {
+		t.Error("expected small session to be ignored")
+	}
+
+	if c.Session(50, 10000, 4000) {
+		t.Error("expected implausible session to be ignored")
+	}
+
+	if !c.Session(50, 10000, 6250) || c.Efficiency != 0.8 {
+		t.Errorf("expected 80%% efficiency, got %v", c.Efficiency)
+	}
+}
+
+func TestCurveStore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "curves.json")
+
+	if err := ConfigureCurves(file); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { curves.file = "" }()
+
+	c := LoadCurve("foo")
+	c.Efficiency = 0.8
+	c.Sample(90, 3000, 11000)
+
+	if err := SaveCurves(); err != nil {
+		t.Fatal(err)
+	}
+
+	curves.curves = make(map[string]*Curve)
+	if err := ConfigureCurves(file); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := LoadCurve("foo"); c.Efficiency != 0.8 || c.Power[18] != 3000 {
+		t.Errorf("unexpected curve %+v", c)
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 )
 
-const chargeEfficiency = 0.9 // assume charge 90% efficiency
+const chargeEfficiency = 0.9 // assume charge 90% efficiency if not learned
 
 // Estimator provides vehicle soc and charge duration
 // Vehicle SoC can be estimated to provide more granularity
@@ -25,6 +25,11 @@ type Estimator struct {
 	prevSoC           float64 // previous vehicle SoC in %
 	prevChargedEnergy float64 // previous charged energy in Wh
 	energyPerSocStep  float64 // Energy per SoC percent in Wh
+
+	curve         *Curve  // learned charge curve
+	fetchedSoC    float64 // last soc received from vehicle or charger
+	sessionSoC    float64 // soc at start of learning session
+	sessionEnergy float64 // charged energy at start of learning session
 }
 
 // NewEstimator creates new estimator
@@ -34,6 +39,7 @@ func NewEstimator(log *util.Logger, charger api.Charger, vehicle api.Vehicle, es
 		charger:  charger,
 		vehicle:  vehicle,
 		estimate: estimate,
+		curve:    new(Curve),
 	}
 
 	s.Reset()
@@ -45,11 +51,52 @@ func NewEstimator(log *util.Logger, charger api.Charger, vehicle api.Vehicle, es
 func (s *Estimator) Reset() {
 	s.prevSoC = 0
 	s.prevChargedEnergy = 0
-	s.capacity = float64(s.vehicle.Capacity()) * 1e3  // cache to simplify debugging
-	s.virtualCapacity = s.capacity / chargeEfficiency // initial capacity taking efficiency into account
+	s.sessionSoC = 0
+	s.capacity = float64(s.vehicle.Capacity()) * 1e3 // cache to simplify debugging
+	s.resetCapacity()
+}
+
+// resetCapacity sets the initial virtual capacity taking efficiency into account
+func (s *Estimator) resetCapacity() {
+	efficiency := chargeEfficiency
+	if s.curve.Efficiency > 0 {
+		efficiency = s.curve.Efficiency
+	}
+
+	s.virtualCapacity = s.capacity / efficiency
 	s.energyPerSocStep = s.virtualCapacity / 100
 }
 
+// SetCurve assigns the vehicle's learned charge curve
+func (s *Estimator) SetCurve(curve *Curve) {
+	s.curve = curve
+	s.resetCapacity()
+}
+
+// Sample adds a charge power sample at the current soc to the charge curve.
+// The offered power is the power available from the charger.
+func (s *Estimator) Sample(chargePower, offeredPower float64) {
+	if s.curve.Sample(s.vehicleSoc, chargePower, offeredPower) {
+		s.log.TRACE.Printf("charge curve: %.0fW @ %.0f%%", chargePower, s.vehicleSoc)
+	}
+}
+
+// Complete learns the charge efficiency from the session's soc increase and charged energy
+// and persists the charge curve
+func (s *Estimator) Complete(chargedEnergy float64) {
+	if s.sessionSoC > 0 && s.curve.Session(s.fetchedSoC-s.sessionSoC, s.capacity, chargedEnergy-s.sessionEnergy) {
+		s.log.DEBUG.Printf("charge efficiency updated: %.0f%%", 100*s.curve.Efficiency)
+	}
+
+	// start new session
+	s.sessionSoC = s.fetchedSoC
+	s.sessionEnergy = chargedEnergy
+
+	if err := SaveCurves(); err != nil {
+		s.log.ERROR.Printf("charge curve: %v", err)
+	}
+}
+
 // RemainingChargeDuration returns the remaining duration estimate based on SoC, target and charge power
 func (s *Estimator) RemainingChargeDuration(chargePower float64, targetSoC int) time.Duration {
 	if chargePower > 0 {
@@ -71,9 +118,8 @@ func (s *Estimator) RemainingChargeDuration(chargePower float64, targetSoC int)
 			}
 		}
 
-		// estimate remaining time
-		whRemaining := percentRemaining / 100 * s.virtualCapacity
-		return time.Duration(float64(time.Hour) * whRemaining / chargePower).Round(time.Second)
+		// estimate remaining time using the learned charge curve
+		return s.curve.Duration(s.vehicleSoc, float64(targetSoC), chargePower, s.energyPerSocStep)
 	}
 
 	return -1
@@ -138,6 +184,14 @@ func (s *Estimator) SoC(chargedEnergy float64) (float64, error) {
 		s.vehicleSoc = f
 	}
 
+	s.fetchedSoC = *fetchedSoC
+
+	// soc at session start for learning efficiency
+	if s.sessionSoC == 0 {
+		s.sessionSoC = *fetchedSoC
+		s.sessionEnergy = chargedEnergy
+	}
+
 	if s.estimate {
 		socDelta := s.vehicleSoc - s.prevSoC
 		energyDelta := math.Max(chargedEnergy, 0) - s.prevChargedEnergy
@@ -220,3 +220,24 @@ func TestSoCFromChargerAndVehicleWithErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestRemainingChargeDurationCurve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := mock.NewMockCharger(ctrl)
+	vehicle := mock.NewMockVehicle(ctrl)
+	// 8 kWh at 80% learned efficiency => 10 kWh virtual capacity
+	vehicle.EXPECT().Capacity().Return(int64(8))
+
+	ce := NewEstimator(util.NewLogger("foo"), charger, vehicle, false)
+	ce.SetCurve(&Curve{Efficiency: 0.8})
+	ce.vehicleSoc = 70.0
+
+	// vehicle accepts half the power above 80%
+	for soc := 80.0; soc < 100; soc += 5 {
+		ce.curve.Sample(soc, 500, 1000)
+	}
+
+	if remaining := ce.RemainingChargeDuration(1000, 90); remaining != 3*time.Hour {
+		t.Errorf("wrong remaining charge duration: %v", remaining)
+	}
+}
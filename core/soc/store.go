@@ -0,0 +1,81 @@
+package soc
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// curveStore persists the learned charge curves by vehicle
+type curveStore struct {
+	mu     sync.Mutex
+	file   string
+	curves map[string]*Curve
+}
+
+var curves = &curveStore{
+	curves: make(map[string]*Curve),
+}
+
+// ConfigureCurves loads the learned charge curves from file.
+// Curves are only kept in memory if not configured.
+func ConfigureCurves(file string) error {
+	curves.mu.Lock()
+	defer curves.mu.Unlock()
+
+	curves.file = file
+
+	b, err := ioutil.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err == nil {
+		err = json.Unmarshal(b, &curves.curves)
+	}
+
+	return err
+}
+
+// LoadCurve returns the charge curve of the vehicle
+func LoadCurve(vehicle string) *Curve {
+	curves.mu.Lock()
+	defer curves.mu.Unlock()
+
+	c, ok := curves.curves[vehicle]
+	if !ok || c == nil {
+		c = new(Curve)
+		curves.curves[vehicle] = c
+	}
+
+	return c
+}
+
+// SaveCurves persists all charge curves
+func SaveCurves() error {
+	curves.mu.Lock()
+	defer curves.mu.Unlock()
+
+	if curves.file == "" {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(curves.curves, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(curves.file), 0755); err != nil {
+		return err
+	}
+
+	tmp := curves.file + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, curves.file)
+}
@@ -13,8 +13,13 @@ import (
 //go:embed dist
 var assets embed.FS
 
-// init loads embedded assets unless live assets are already loaded
+//go:embed schema.json
+var schema []byte
+
+// init provides the config schema and loads embedded assets unless live assets are already loaded
 func init() {
+	cmd.Schema = schema
+
 	if server.Assets == nil {
 		fsys, err := fs.Sub(assets, "dist")
 		if err != nil {
@@ -0,0 +1,231 @@
+package meter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+/*
+The composite meter combines configured meters by reference. Member readings are added or, if
+subtract is set, subtracted. Battery soc is averaged weighted by the member's capacity.
+
+** Example configuration **
+meters:
+- name: inverter1
+  ...
+- name: inverter2
+  ...
+- name: pv
+  type: composite
+  meters:
+  - ref: inverter1
+  - ref: inverter2
+*/
+
+// compositeMember is a referenced meter
+type compositeMember struct {
+	ref      string
+	meter    api.Meter
+	subtract bool
+	capacity float64
+}
+
+// sign returns the factor applied to the member's readings
+func (m compositeMember) sign() float64 {
+	if m.subtract {
+		return -1
+	}
+	return 1
+}
+
+// Composite is an api.Meter implementation combining multiple meters
+type Composite struct {
+	members []compositeMember
+}
+
+func init() {
+	registry.Add("composite", NewCompositeFromConfig)
+}
+
+//go:generate go run ../cmd/tools/decorate.go -f decorateComposite -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.MeterCurrent,Currents,func() (float64, float64, float64, error)" -t "api.Battery,SoC,func() (float64, error)"
+
+// NewCompositeFromConfig creates a composite meter from generic config
+func NewCompositeFromConfig(other map[string]interface{}) (api.Meter, error) {
+	var cc struct {
+		Meters []struct {
+			Ref      string
+			Subtract bool
+			Capacity float64 // battery capacity in kWh
+		}
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if len(cc.Meters) == 0 {
+		return nil, errors.New("missing meters")
+	}
+
+	var members []compositeMember
+	for _, m := range cc.Meters {
+		meter, err := Instance(m.Ref)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, compositeMember{
+			ref:      m.Ref,
+			meter:    meter,
+			subtract: m.Subtract,
+			capacity: m.Capacity,
+		})
+	}
+
+	return newComposite(members)
+}
+
+// newComposite creates a composite meter. Energy and currents are provided if all members provide them,
+// soc is provided if any member is a battery.
+func newComposite(members []compositeMember) (api.Meter, error) {
+	m := &Composite{members: members}
+
+	energy, currents, soc := true, true, false
+	for _, member := range members {
+		if _, ok := member.meter.(api.MeterEnergy); !ok {
+			energy = false
+		}
+		if _, ok := member.meter.(api.MeterCurrent); !ok {
+			currents = false
+		}
+		if _, ok := member.meter.(api.Battery); ok {
+			soc = true
+		}
+	}
+
+	var totalEnergy func() (float64, error)
+	if energy {
+		totalEnergy = m.totalEnergy
+	}
+
+	var phaseCurrents func() (float64, float64, float64, error)
+	if currents {
+		phaseCurrents = m.currents
+	}
+
+	var batterySoC func() (float64, error)
+	if soc {
+		batterySoC = m.soc
+	}
+
+	return decorateComposite(m, totalEnergy, phaseCurrents, batterySoC), nil
+}
+
+// compositeError collects the errors of failed members
+type compositeError []error
+
+func (e compositeError) Error() string {
+	var s []string
+	for _, err := range e {
+		s = append(s, err.Error())
+	}
+	return strings.Join(s, ", ")
+}
+
+// Unwrap returns the first member error
+func (e compositeError) Unwrap() error {
+	return e[0]
+}
+
+// each calls fn for every member and collects member errors
+func (m *Composite) each(fn func(member compositeMember) error) error {
+	var errs compositeError
+	for _, member := range m.members {
+		if err := fn(member); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", member.ref, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// CurrentPower implements the api.Meter interface
+func (m *Composite) CurrentPower() (float64, error) {
+	var res float64
+
+	err := m.each(func(member compositeMember) error {
+		power, err := member.meter.CurrentPower()
+		res += member.sign() * power
+		return err
+	})
+
+	return res, err
+}
+
+// totalEnergy implements the api.MeterEnergy interface
+func (m *Composite) totalEnergy() (float64, error) {
+	var res float64
+
+	err := m.each(func(member compositeMember) error {
+		energy, err := member.meter.(api.MeterEnergy).TotalEnergy()
+		res += member.sign() * energy
+		return err
+	})
+
+	return res, err
+}
+
+// currents implements the api.MeterCurrent interface
+func (m *Composite) currents() (float64, float64, float64, error) {
+	var res [3]float64
+
+	err := m.each(func(member compositeMember) error {
+		i1, i2, i3, err := member.meter.(api.MeterCurrent).Currents()
+		for i, c := range []float64{i1, i2, i3} {
+			res[i] += member.sign() * c
+		}
+		return err
+	})
+
+	return res[0], res[1], res[2], err
+}
+
+// soc implements the api.Battery interface
+func (m *Composite) soc() (float64, error) {
+	var soc, capacity float64
+
+	err := m.each(func(member compositeMember) error {
+		battery, ok := member.meter.(api.Battery)
+		if !ok {
+			return nil
+		}
+
+		// equal weight if capacity is not configured
+		weight := member.capacity
+		if weight == 0 {
+			weight = 1
+		}
+
+		val, err := battery.SoC()
+		if err == nil {
+			soc += weight * val
+			capacity += weight
+		}
+
+		return err
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return soc / capacity, nil
+}
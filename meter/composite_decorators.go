@@ -0,0 +1,137 @@
+package meter
+
+// Code generated by github.com/andig/cmd/tools/decorate.go. DO NOT EDIT.
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+func decorateComposite(base api.Meter, meterEnergy func() (float64, error), meterCurrent func() (float64, float64, float64, error), battery func() (float64, error)) api.Meter {
+	switch {
+	case battery == nil && meterCurrent == nil && meterEnergy == nil:
+		return base
+
+	case battery == nil && meterCurrent == nil && meterEnergy != nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+		}{
+			Meter: base,
+			MeterEnergy: &decorateCompositeMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case battery == nil && meterCurrent != nil && meterEnergy == nil:
+		return &struct {
+			api.Meter
+			api.MeterCurrent
+		}{
+			Meter: base,
+			MeterCurrent: &decorateCompositeMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+
+	case battery == nil && meterCurrent != nil && meterEnergy != nil:
+		return &struct {
+			api.Meter
+			api.MeterCurrent
+			api.MeterEnergy
+		}{
+			Meter: base,
+			MeterCurrent: &decorateCompositeMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+			MeterEnergy: &decorateCompositeMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case battery != nil && meterCurrent == nil && meterEnergy == nil:
+		return &struct {
+			api.Meter
+			api.Battery
+		}{
+			Meter: base,
+			Battery: &decorateCompositeBatteryImpl{
+				battery: battery,
+			},
+		}
+
+	case battery != nil && meterCurrent == nil && meterEnergy != nil:
+		return &struct {
+			api.Meter
+			api.Battery
+			api.MeterEnergy
+		}{
+			Meter: base,
+			Battery: &decorateCompositeBatteryImpl{
+				battery: battery,
+			},
+			MeterEnergy: &decorateCompositeMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case battery != nil && meterCurrent != nil && meterEnergy == nil:
+		return &struct {
+			api.Meter
+			api.Battery
+			api.MeterCurrent
+		}{
+			Meter: base,
+			Battery: &decorateCompositeBatteryImpl{
+				battery: battery,
+			},
+			MeterCurrent: &decorateCompositeMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+
+	case battery != nil && meterCurrent != nil && meterEnergy != nil:
+		return &struct {
+			api.Meter
+			api.Battery
+			api.MeterCurrent
+			api.MeterEnergy
+		}{
+			Meter: base,
+			Battery: &decorateCompositeBatteryImpl{
+				battery: battery,
+			},
+			MeterCurrent: &decorateCompositeMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+			MeterEnergy: &decorateCompositeMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+	}
+
+	return nil
+}
+
+type decorateCompositeBatteryImpl struct {
+	battery func() (float64, error)
+}
+
+func (impl *decorateCompositeBatteryImpl) SoC() (float64, error) {
+	return impl.battery()
+}
+
+type decorateCompositeMeterCurrentImpl struct {
+	meterCurrent func() (float64, float64, float64, error)
+}
+
+func (impl *decorateCompositeMeterCurrentImpl) Currents() (float64, float64, float64, error) {
+	return impl.meterCurrent()
+}
+
+type decorateCompositeMeterEnergyImpl struct {
+	meterEnergy func() (float64, error)
+}
+
+func (impl *decorateCompositeMeterEnergyImpl) TotalEnergy() (float64, error) {
+	return impl.meterEnergy()
+}
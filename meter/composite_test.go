@@ -0,0 +1,82 @@
+package meter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func TestComposite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type energyMeter struct {
+		*mock.MockMeter
+		*mock.MockMeterEnergy
+	}
+
+	type batteryMeter struct {
+		*mock.MockMeter
+		*mock.MockMeterEnergy
+		*mock.MockBattery
+	}
+
+	inv := &energyMeter{mock.NewMockMeter(ctrl), mock.NewMockMeterEnergy(ctrl)}
+	wb := &energyMeter{mock.NewMockMeter(ctrl), mock.NewMockMeterEnergy(ctrl)}
+	bat1 := &batteryMeter{mock.NewMockMeter(ctrl), mock.NewMockMeterEnergy(ctrl), mock.NewMockBattery(ctrl)}
+	bat2 := &batteryMeter{mock.NewMockMeter(ctrl), mock.NewMockMeterEnergy(ctrl), mock.NewMockBattery(ctrl)}
+
+	m, err := newComposite([]compositeMember{
+		{ref: "inverter", meter: inv},
+		{ref: "wallbox", meter: wb, subtract: true},
+		{ref: "battery1", meter: bat1, capacity: 10},
+		{ref: "battery2", meter: bat2, capacity: 5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := m.(api.MeterCurrent); ok {
+		t.Error("unexpected currents")
+	}
+
+	inv.MockMeter.EXPECT().CurrentPower().Return(5000.0, nil)
+	wb.MockMeter.EXPECT().CurrentPower().Return(2000.0, nil)
+	bat1.MockMeter.EXPECT().CurrentPower().Return(-500.0, nil)
+	bat2.MockMeter.EXPECT().CurrentPower().Return(100.0, nil)
+
+	if p, err := m.CurrentPower(); p != 2600 || err != nil {
+		t.Errorf("power: expected 2600, got %.0f %v", p, err)
+	}
+
+	inv.MockMeterEnergy.EXPECT().TotalEnergy().Return(100.0, nil)
+	wb.MockMeterEnergy.EXPECT().TotalEnergy().Return(20.0, nil)
+	bat1.MockMeterEnergy.EXPECT().TotalEnergy().Return(5.0, nil)
+	bat2.MockMeterEnergy.EXPECT().TotalEnergy().Return(5.0, nil)
+
+	if e, err := m.(api.MeterEnergy).TotalEnergy(); e != 90 || err != nil {
+		t.Errorf("energy: expected 90, got %.0f %v", e, err)
+	}
+
+	bat1.MockBattery.EXPECT().SoC().Return(80.0, nil)
+	bat2.MockBattery.EXPECT().SoC().Return(20.0, nil)
+
+	if soc, err := m.(api.Battery).SoC(); soc != 60 || err != nil {
+		t.Errorf("soc: expected 60, got %.0f %v", soc, err)
+	}
+
+	// member errors
+	errTimeout := errors.New("timeout")
+	inv.MockMeter.EXPECT().CurrentPower().Return(0.0, errTimeout)
+	wb.MockMeter.EXPECT().CurrentPower().Return(2000.0, nil)
+	bat1.MockMeter.EXPECT().CurrentPower().Return(0.0, errTimeout)
+	bat2.MockMeter.EXPECT().CurrentPower().Return(100.0, nil)
+
+	_, err = m.CurrentPower()
+	if !errors.Is(err, errTimeout) || err.Error() != "inverter: timeout, battery1: timeout" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package meter
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/evcc-io/evcc/api"
 )
@@ -26,6 +27,15 @@ func (r meterRegistry) Get(name string) (func(map[string]interface{}) (api.Meter
 
 var registry meterRegistry = make(map[string]func(map[string]interface{}) (api.Meter, error))
 
+// Types returns the list of meter types
+func Types() []string {
+	var res []string
+	for typ := range registry {
+		res = append(res, typ)
+	}
+	return res
+}
+
 // NewFromConfig creates meter from configuration
 func NewFromConfig(typ string, other map[string]interface{}) (v api.Meter, err error) {
 	factory, err := registry.Get(strings.ToLower(typ))
@@ -39,3 +49,29 @@ func NewFromConfig(typ string, other map[string]interface{}) (v api.Meter, err e
 
 	return
 }
+
+// instances are the configured meters available for reference by other meters
+var (
+	instancesMu sync.RWMutex
+	instances   = make(map[string]api.Meter)
+)
+
+// AddInstance makes a configured meter available for reference by name
+func AddInstance(name string, m api.Meter) {
+	instancesMu.Lock()
+	defer instancesMu.Unlock()
+
+	instances[name] = m
+}
+
+// Instance returns a configured meter by name. Referenced meters must be configured before the referencing meter.
+func Instance(name string) (api.Meter, error) {
+	instancesMu.RLock()
+	defer instancesMu.RUnlock()
+
+	m, exists := instances[name]
+	if !exists {
+		return nil, fmt.Errorf("meter not found: %s", name)
+	}
+	return m, nil
+}
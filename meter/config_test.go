@@ -1,6 +1,8 @@
 package meter
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/evcc-io/evcc/util/test"
@@ -33,3 +35,24 @@ func TestMeters(t *testing.T) {
 		})
 	}
 }
+
+func TestInstances(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			AddInstance(name, nil)
+			if _, err := Instance(name); err != nil {
+				t.Error(err)
+			}
+		}(fmt.Sprintf("meter%d", i))
+	}
+
+	wg.Wait()
+
+	if _, err := Instance("foo"); err == nil {
+		t.Error("expected error for undefined meter")
+	}
+}
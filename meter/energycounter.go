@@ -0,0 +1,220 @@
+package meter
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+func init() {
+	registry.Add("energycounter", NewEnergyCounterFromConfig)
+}
+
+// NewEnergyCounterFromConfig creates api.Meter from config. The wrapped meter's power is
+// integrated into import and export energy counters which are persisted to file.
+func NewEnergyCounterFromConfig(other map[string]interface{}) (api.Meter, error) {
+	cc := struct {
+		File     string
+		Export   bool          // provide export instead of import energy
+		MaxGap   time.Duration // don't integrate across longer gaps between readings
+		Interval time.Duration // state file write interval
+		Meter    struct {
+			Type  string
+			Other map[string]interface{} `mapstructure:",remain"`
+		}
+	}{
+		MaxGap:   5 * time.Minute,
+		Interval: time.Minute,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.File == "" {
+		return nil, errors.New("missing file")
+	}
+
+	m, err := NewFromConfig(cc.Meter.Type, cc.Meter.Other)
+	if err != nil {
+		return nil, err
+	}
+
+	ec, err := NewEnergyCounter(m, cc.File, cc.MaxGap, cc.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	ec.export = cc.Export
+
+	// decorate battery reading
+	var batterySoC func() (float64, error)
+	if m, ok := m.(api.Battery); ok {
+		batterySoC = m.SoC
+	}
+
+	// decorate currents reading
+	var currents func() (float64, float64, float64, error)
+	if m, ok := m.(api.MeterCurrent); ok {
+		currents = m.Currents
+	}
+
+	return decorateEnergyCounter(ec, currents, batterySoC), nil
+}
+
+// energyCounterState is the persisted counter state
+type energyCounterState struct {
+	Import float64 `json:"import"` // kWh
+	Export float64 `json:"export"` // kWh
+}
+
+//go:generate go run ../cmd/tools/decorate.go -f decorateEnergyCounter -b *EnergyCounter -r api.Meter -t "api.MeterCurrent,Currents,func() (float64, float64, float64, error)" -t "api.Battery,SoC,func() (float64, error)"
+
+// EnergyCounter integrates power readings into import (positive power) and export (negative power) energy
+type EnergyCounter struct {
+	mu       sync.Mutex
+	log      *util.Logger
+	clock    clock.Clock
+	meter    api.Meter
+	file     string
+	maxGap   time.Duration
+	interval time.Duration
+	export   bool // total energy is export energy
+
+	state   energyCounterState
+	power   float64
+	updated time.Time
+	saved   time.Time
+}
+
+// NewEnergyCounter creates an energy counter restoring the counter state from file
+func NewEnergyCounter(meter api.Meter, file string, maxGap, interval time.Duration) (*EnergyCounter, error) {
+	ec := &EnergyCounter{
+		log:      util.NewLogger("energy"),
+		clock:    clock.New(),
+		meter:    meter,
+		file:     file,
+		maxGap:   maxGap,
+		interval: interval,
+	}
+
+	b, err := ioutil.ReadFile(file)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(b, &ec.state); err != nil {
+			return nil, err
+		}
+		ec.log.DEBUG.Printf("restored %s: import %.3fkWh, export %.3fkWh", file, ec.state.Import, ec.state.Export)
+
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+
+	return ec, nil
+}
+
+// CurrentPower implements the api.Meter interface
+func (ec *EnergyCounter) CurrentPower() (float64, error) {
+	power, err := ec.meter.CurrentPower()
+	if err != nil {
+		return power, err
+	}
+
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	ec.add(power)
+
+	return power, nil
+}
+
+// add integrates power since the last reading
+func (ec *EnergyCounter) add(power float64) {
+	now := ec.clock.Now()
+
+	if elapsed := now.Sub(ec.updated); !ec.updated.IsZero() && elapsed <= ec.maxGap {
+		// integrate positive and negative power separately if sign changed
+		for _, p := range []float64{ec.power, power} {
+			energy := p / 2 * elapsed.Hours() / 1e3
+
+			if energy > 0 {
+				ec.state.Import += energy
+			} else {
+				ec.state.Export -= energy
+			}
+		}
+	}
+
+	ec.power = power
+	ec.updated = now
+
+	if now.Sub(ec.saved) >= ec.interval {
+		if err := ec.save(); err != nil {
+			ec.log.ERROR.Printf("save: %v", err)
+		}
+	}
+}
+
+// save writes the counter state
+func (ec *EnergyCounter) save() error {
+	b, err := json.Marshal(ec.state)
+	if err != nil {
+		return err
+	}
+
+	// write atomically
+	tmp := filepath.Join(filepath.Dir(ec.file), "."+filepath.Base(ec.file)+".tmp")
+	if err := ioutil.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, ec.file); err != nil {
+		return err
+	}
+
+	ec.saved = ec.clock.Now()
+
+	return nil
+}
+
+// ImportEnergy returns the energy imported in kWh
+func (ec *EnergyCounter) ImportEnergy() (float64, error) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	return ec.state.Import, nil
+}
+
+// ExportEnergy returns the energy exported in kWh
+func (ec *EnergyCounter) ExportEnergy() (float64, error) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	return ec.state.Export, nil
+}
+
+var _ api.MeterEnergy = (*EnergyCounter)(nil)
+
+// TotalEnergy implements the api.MeterEnergy interface
+func (ec *EnergyCounter) TotalEnergy() (float64, error) {
+	if ec.export {
+		return ec.ExportEnergy()
+	}
+	return ec.ImportEnergy()
+}
+
+var _ io.Closer = (*EnergyCounter)(nil)
+
+// Close saves the counter state
+func (ec *EnergyCounter) Close() error {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	return ec.save()
+}
@@ -0,0 +1,69 @@
+package meter
+
+// Code generated by github.com/andig/cmd/tools/decorate.go. DO NOT EDIT.
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+func decorateEnergyCounter(base *EnergyCounter, meterCurrent func() (float64, float64, float64, error), battery func() (float64, error)) api.Meter {
+	switch {
+	case battery == nil && meterCurrent == nil:
+		return base
+
+	case battery == nil && meterCurrent != nil:
+		return &struct {
+			*EnergyCounter
+			api.MeterCurrent
+		}{
+			EnergyCounter: base,
+			MeterCurrent: &decorateEnergyCounterMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+
+	case battery != nil && meterCurrent == nil:
+		return &struct {
+			*EnergyCounter
+			api.Battery
+		}{
+			EnergyCounter: base,
+			Battery: &decorateEnergyCounterBatteryImpl{
+				battery: battery,
+			},
+		}
+
+	case battery != nil && meterCurrent != nil:
+		return &struct {
+			*EnergyCounter
+			api.Battery
+			api.MeterCurrent
+		}{
+			EnergyCounter: base,
+			Battery: &decorateEnergyCounterBatteryImpl{
+				battery: battery,
+			},
+			MeterCurrent: &decorateEnergyCounterMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+	}
+
+	return nil
+}
+
+type decorateEnergyCounterBatteryImpl struct {
+	battery func() (float64, error)
+}
+
+func (impl *decorateEnergyCounterBatteryImpl) SoC() (float64, error) {
+	return impl.battery()
+}
+
+type decorateEnergyCounterMeterCurrentImpl struct {
+	meterCurrent func() (float64, float64, float64, error)
+}
+
+func (impl *decorateEnergyCounterMeterCurrentImpl) Currents() (float64, float64, float64, error) {
+	return impl.meterCurrent()
+}
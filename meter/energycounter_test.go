@@ -0,0 +1,83 @@
+package meter
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func TestEnergyCounter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	file := filepath.Join(t.TempDir(), "energy.json")
+	clck := clock.NewMock()
+
+	newCounter := func(m *mock.MockMeter) *EnergyCounter {
+		ec, err := NewEnergyCounter(m, file, 5*time.Minute, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ec.clock = clck
+		return ec
+	}
+
+	expect := func(ec *EnergyCounter, imp, exp float64) {
+		t.Helper()
+		if i, _ := ec.ImportEnergy(); math.Abs(i-imp) > 1e-9 {
+			t.Errorf("import: expected %.3f, got %.3f", imp, i)
+		}
+		if e, _ := ec.ExportEnergy(); math.Abs(e-exp) > 1e-9 {
+			t.Errorf("export: expected %.3f, got %.3f", exp, e)
+		}
+	}
+
+	m := mock.NewMockMeter(ctrl)
+	ec := newCounter(m)
+
+	for _, tc := range []struct {
+		power    float64
+		elapsed  time.Duration
+		imp, exp float64
+	}{
+		{1000, 0, 0, 0},                            // first reading
+		{1000, time.Minute, 1.0 / 60, 0},           // 1kW for 1 minute
+		{-2000, time.Minute, 1.5 / 60, 1.0 / 60},   // sign change splits the interval
+		{-2000, time.Minute, 1.5 / 60, 3.0 / 60},   // 2kW export for 1 minute
+		{-2000, time.Hour, 1.5 / 60, 3.0 / 60},     // gap is not integrated
+		{0, 2 * time.Minute, 1.5 / 60, 5.0 / 60},   // ramp down
+		{600, 5 * time.Minute, 3.0 / 60, 5.0 / 60}, // ramp up
+	} {
+		clck.Add(tc.elapsed)
+		m.EXPECT().CurrentPower().Return(tc.power, nil)
+
+		if p, err := ec.CurrentPower(); p != tc.power || err != nil {
+			t.Errorf("unexpected power %.0f %v", p, err)
+		}
+
+		expect(ec, tc.imp, tc.exp)
+	}
+
+	// restored from file
+	expect(newCounter(mock.NewMockMeter(ctrl)), 3.0/60, 5.0/60)
+
+	// saved on close within write interval
+	clck.Add(30 * time.Second)
+	m.EXPECT().CurrentPower().Return(600.0, nil)
+	if _, err := ec.CurrentPower(); err != nil {
+		t.Fatal(err)
+	}
+
+	expect(newCounter(mock.NewMockMeter(ctrl)), 3.0/60, 5.0/60)
+
+	if err := ec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	expect(newCounter(mock.NewMockMeter(ctrl)), 3.3/60, 5.0/60)
+}
@@ -0,0 +1,180 @@
+package meter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+func init() {
+	registry.Add("filter", NewFilterFromConfig)
+}
+
+// FilterConfig defines the filter settings. Zero values disable the respective filter.
+type FilterConfig struct {
+	Invert   bool          // invert power sign, energy and currents are passed through unchanged
+	Min, Max *float64      // plausible power range
+	MaxStep  float64       // maximum power change between readings unless confirmed by the next reading
+	Median   int           // median window size
+	Hold     time.Duration // maximum age of the last value returned in case of errors or rejected readings
+}
+
+// NewFilterFromConfig creates api.Meter from config
+func NewFilterFromConfig(other map[string]interface{}) (api.Meter, error) {
+	var cc struct {
+		FilterConfig `mapstructure:",squash"`
+		Meter        struct {
+			Type  string
+			Other map[string]interface{} `mapstructure:",remain"`
+		}
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Min != nil && cc.Max != nil && *cc.Min >= *cc.Max {
+		return nil, errors.New("min must be smaller than max")
+	}
+
+	m, err := NewFromConfig(cc.Meter.Type, cc.Meter.Other)
+	if err != nil {
+		return nil, err
+	}
+
+	f := NewFilter(m.CurrentPower, cc.FilterConfig)
+
+	meter, _ := NewConfigurable(f.CurrentPower)
+
+	// decorate energy reading
+	var totalEnergy func() (float64, error)
+	if m, ok := m.(api.MeterEnergy); ok {
+		totalEnergy = m.TotalEnergy
+	}
+
+	// decorate battery reading
+	var batterySoC func() (float64, error)
+	if m, ok := m.(api.Battery); ok {
+		batterySoC = m.SoC
+	}
+
+	// decorate currents reading
+	var currents func() (float64, float64, float64, error)
+	if m, ok := m.(api.MeterCurrent); ok {
+		currents = m.Currents
+	}
+
+	return meter.Decorate(totalEnergy, currents, batterySoC), nil
+}
+
+// Filter rejects implausible power readings and smoothes outliers
+type Filter struct {
+	mu       sync.Mutex
+	log      *util.Logger
+	clock    clock.Clock
+	conf     FilterConfig
+	powerG   func() (float64, error)
+	window   []float64
+	last     float64   // last accepted reading
+	updated  time.Time // last accepted reading timestamp
+	result   float64   // last filtered value
+	pending  *float64  // step change awaiting confirmation
+	accepted bool
+}
+
+// NewFilter creates a power filter
+func NewFilter(powerG func() (float64, error), conf FilterConfig) *Filter {
+	return &Filter{
+		log:    util.NewLogger("filter"),
+		clock:  clock.New(),
+		conf:   conf,
+		powerG: powerG,
+	}
+}
+
+// CurrentPower implements the api.Meter interface
+func (f *Filter) CurrentPower() (float64, error) {
+	power, err := f.powerG()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err == nil {
+		if f.conf.Invert {
+			power = -power
+		}
+
+		err = f.validate(power)
+	}
+
+	if err != nil {
+		return f.hold(err)
+	}
+
+	f.last = power
+	f.updated = f.clock.Now()
+	f.accepted = true
+	f.result = f.median(power)
+
+	return f.result, nil
+}
+
+// validate checks plausibility and step change of the reading
+func (f *Filter) validate(power float64) error {
+	if f.conf.Min != nil && power < *f.conf.Min || f.conf.Max != nil && power > *f.conf.Max {
+		return fmt.Errorf("implausible power: %.0fW", power)
+	}
+
+	if f.conf.MaxStep == 0 || !f.accepted || math.Abs(power-f.last) <= f.conf.MaxStep {
+		f.pending = nil
+		return nil
+	}
+
+	// step change confirmed by consecutive reading
+	if f.pending != nil && math.Abs(power-*f.pending) <= f.conf.MaxStep {
+		f.pending = nil
+		return nil
+	}
+
+	f.pending = &power
+
+	return fmt.Errorf("step change: %.0fW to %.0fW", f.last, power)
+}
+
+// hold returns the last filtered value if not older than the hold time
+func (f *Filter) hold(err error) (float64, error) {
+	if f.accepted && f.conf.Hold > 0 && f.clock.Since(f.updated) <= f.conf.Hold {
+		f.log.DEBUG.Printf("%v, holding %.0fW", err, f.result)
+		return f.result, nil
+	}
+
+	return 0, err
+}
+
+// median adds the reading to the window and returns the window's median
+func (f *Filter) median(power float64) float64 {
+	if f.conf.Median <= 1 {
+		return power
+	}
+
+	f.window = append(f.window, power)
+	if len(f.window) > f.conf.Median {
+		f.window = f.window[1:]
+	}
+
+	sorted := append([]float64{}, f.window...)
+	sort.Float64s(sorted)
+
+	if n := len(sorted); n%2 == 0 {
+		return (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	return sorted[len(sorted)/2]
+}
@@ -0,0 +1,87 @@
+package meter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+func TestFilter(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	min, max := -10000.0, 10000.0
+
+	type reading struct {
+		power   float64
+		err     error
+		elapsed time.Duration
+		res     float64
+		fail    bool
+	}
+
+	for _, tc := range []struct {
+		name     string
+		conf     FilterConfig
+		readings []reading
+	}{
+		{"invert", FilterConfig{Invert: true}, []reading{
+			{power: 1000, res: -1000},
+			{power: -500, res: 500},
+		}},
+		{"bounds", FilterConfig{Min: &min, Max: &max}, []reading{
+			{power: 1000, res: 1000},
+			{power: 65535, fail: true},
+			{power: -10000, res: -10000},
+		}},
+		{"bounds hold", FilterConfig{Min: &min, Max: &max, Hold: time.Minute}, []reading{
+			{power: 1000, res: 1000},
+			{power: 65535, elapsed: 10 * time.Second, res: 1000},
+			{power: 65535, elapsed: time.Minute, fail: true},
+		}},
+		{"step", FilterConfig{MaxStep: 3000, Hold: time.Minute}, []reading{
+			{power: 1000, res: 1000},
+			{power: 20000, res: 1000},  // spike rejected
+			{power: 1200, res: 1200},   // back to normal
+			{power: 12000, res: 1200},  // step rejected
+			{power: 11000, res: 11000}, // step confirmed
+		}},
+		{"hold", FilterConfig{Hold: time.Minute}, []reading{
+			{err: errFailed, fail: true},
+			{power: 1000, res: 1000},
+			{err: errFailed, elapsed: 30 * time.Second, res: 1000},
+			{err: errFailed, elapsed: 31 * time.Second, fail: true},
+			{power: 2000, res: 2000},
+		}},
+		{"median", FilterConfig{Median: 3}, []reading{
+			{power: 1000, res: 1000},
+			{power: 3000, res: 2000},
+			{power: 2000, res: 2000},
+			{power: 9000, res: 3000},
+			{power: 2500, res: 2500},
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var r reading
+			f := NewFilter(func() (float64, error) { return r.power, r.err }, tc.conf)
+
+			clck := clock.NewMock()
+			f.clock = clck
+
+			for i := range tc.readings {
+				r = tc.readings[i]
+				clck.Add(r.elapsed)
+
+				res, err := f.CurrentPower()
+				if (err != nil) != r.fail {
+					t.Errorf("%d: unexpected error %v", i, err)
+				}
+
+				if err == nil && res != r.res {
+					t.Errorf("%d: expected %.0f, got %.0f", i, r.res, res)
+				}
+			}
+		})
+	}
+}
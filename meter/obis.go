@@ -0,0 +1,296 @@
+package meter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/meter/obis"
+	"github.com/evcc-io/evcc/util"
+)
+
+/*
+The obis meter reads smart meters through an optical IR head, either from a serial device or a TCP socket (e.g. ser2net).
+Telegrams are decoded using the SML or IEC 62056-21 D0 protocol and values are identified by their OBIS code.
+
+Power defaults to 16.7.0 (or 1.7.0 - 2.7.0 if not available), energy to 1.8.0 (use 2.8.0 for export).
+Phase currents are available if the meter sends 31.7.0 (and 51.7.0, 71.7.0 for three phase meters).
+
+** Example configuration **
+meters:
+- name: grid
+  type: obis
+  protocol: sml
+  device: /dev/ttyUSB0
+- name: pv
+  type: obis
+  protocol: d0
+  uri: 192.168.0.20:2000
+  energy: 1-0:2.8.0*255
+*/
+
+// Obis is an api.Meter implementation for SML and D0 smart meters
+type Obis struct {
+	log      *util.Logger
+	mu       sync.Mutex
+	timeout  time.Duration
+	updated  time.Time
+	readings map[string]float64
+	power    string
+	energy   string
+}
+
+func init() {
+	registry.Add("obis", NewObisFromConfig)
+}
+
+//go:generate go run ../cmd/tools/decorate.go -f decorateObis -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.MeterCurrent,Currents,func() (float64, float64, float64, error)"
+
+// NewObisFromConfig creates an obis meter from generic config
+func NewObisFromConfig(other map[string]interface{}) (api.Meter, error) {
+	cc := struct {
+		Protocol            string
+		URI, Device, Comset string
+		Baudrate            int
+		Power, Energy       string
+		Timeout             time.Duration
+	}{
+		Protocol: "sml",
+		Baudrate: 9600,
+		Energy:   obis.Import,
+		Timeout:  30 * time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	var decoder func(io.Reader) obis.Decoder
+
+	switch strings.ToLower(cc.Protocol) {
+	case "sml":
+		decoder = func(r io.Reader) obis.Decoder { return obis.NewSMLDecoder(r) }
+		if cc.Comset == "" {
+			cc.Comset = "8N1"
+		}
+	case "d0":
+		decoder = func(r io.Reader) obis.Decoder { return obis.NewD0Decoder(r) }
+		if cc.Comset == "" {
+			cc.Comset = "7E1"
+		}
+	default:
+		return nil, fmt.Errorf("invalid protocol: %s", cc.Protocol)
+	}
+
+	open := func() (io.ReadCloser, error) {
+		return obis.Open(cc.URI, cc.Device, cc.Baudrate, cc.Comset, cc.Timeout)
+	}
+
+	return NewObis(open, decoder, cc.Power, cc.Energy, cc.Timeout)
+}
+
+// NewObis creates an obis meter. It waits for the first telegram to determine the available values.
+func NewObis(open func() (io.ReadCloser, error), decoder func(io.Reader) obis.Decoder, power, energy string, timeout time.Duration) (api.Meter, error) {
+	m := &Obis{
+		log:      util.NewLogger("obis"),
+		timeout:  timeout,
+		readings: make(map[string]float64),
+	}
+
+	var err error
+	if power != "" {
+		if m.power, err = obis.Normalize(power); err != nil {
+			return nil, err
+		}
+	}
+
+	if m.energy, err = obis.Normalize(energy); err != nil {
+		return nil, err
+	}
+
+	// test the connection before starting the background reader
+	conn, err := open()
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	received := make(chan struct{})
+	go m.run(done, conn, open, decoder, received)
+
+	select {
+	case <-received:
+	case <-time.After(timeout):
+		// stop the reader and release the connection
+		close(done)
+		return nil, errors.New("timeout waiting for telegram")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkPower(); err != nil {
+		close(done)
+		return nil, err
+	}
+
+	var totalEnergy func() (float64, error)
+	if _, ok := m.readings[m.energy]; ok {
+		totalEnergy = m.totalEnergy
+	}
+
+	var currents func() (float64, float64, float64, error)
+	if _, ok := m.readings[obis.CurrentL1]; ok {
+		currents = m.currents
+	}
+
+	return decorateObis(m, totalEnergy, currents), nil
+}
+
+// checkPower verifies that the configured or default power reading is available
+func (m *Obis) checkPower() error {
+	if m.power == "" {
+		if _, ok := m.readings[obis.Power]; !ok {
+			if _, ok := m.readings[obis.PowerImport]; !ok {
+				return fmt.Errorf("no power reading, specify power obis code")
+			}
+		}
+	} else if _, ok := m.readings[m.power]; !ok {
+		return fmt.Errorf("power %s not available", m.power)
+	}
+
+	return nil
+}
+
+// run decodes telegrams and reconnects on errors until done is closed
+func (m *Obis) run(done chan struct{}, conn io.ReadCloser, open func() (io.ReadCloser, error), decoder func(io.Reader) obis.Decoder, received chan struct{}) {
+	var once sync.Once
+
+	for {
+		// close the connection on error or when done to abort blocking reads
+		stop := make(chan struct{})
+		go func(conn io.Closer) {
+			select {
+			case <-done:
+			case <-stop:
+			}
+			conn.Close()
+		}(conn)
+
+		dec := decoder(conn)
+
+		for {
+			res, err := dec.Decode()
+			if errors.Is(err, obis.ErrInvalid) {
+				m.log.DEBUG.Println(err)
+				continue
+			}
+
+			if err != nil {
+				select {
+				case <-done:
+				default:
+					m.log.ERROR.Println(err)
+				}
+				break
+			}
+
+			m.update(res)
+			once.Do(func() { close(received) })
+		}
+
+		close(stop)
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(time.Second):
+			}
+
+			var err error
+			if conn, err = open(); err == nil {
+				break
+			}
+
+			m.log.ERROR.Println(err)
+		}
+	}
+}
+
+// update stores the telegram's readings
+func (m *Obis) update(res []obis.Reading) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, r := range res {
+		m.log.TRACE.Printf("%s: %v%s", r.Code, r.Value, r.Unit)
+		m.readings[r.Code] = r.Value
+	}
+
+	m.updated = time.Now()
+}
+
+// get returns the latest reading for code
+func (m *Obis) get(code string) (float64, error) {
+	if elapsed := time.Since(m.updated); elapsed > m.timeout {
+		return 0, fmt.Errorf("outdated: %v", elapsed.Truncate(time.Second))
+	}
+
+	val, ok := m.readings[code]
+	if !ok {
+		return 0, fmt.Errorf("%s not available", code)
+	}
+
+	return val, nil
+}
+
+// CurrentPower implements the api.Meter interface
+func (m *Obis) CurrentPower() (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.power != "" {
+		return m.get(m.power)
+	}
+
+	if _, ok := m.readings[obis.Power]; ok {
+		return m.get(obis.Power)
+	}
+
+	imp, err := m.get(obis.PowerImport)
+	if err != nil {
+		return 0, err
+	}
+
+	// export power is optional
+	exp, _ := m.get(obis.PowerExport)
+
+	return imp - exp, nil
+}
+
+// totalEnergy implements the api.MeterEnergy interface
+func (m *Obis) totalEnergy() (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.get(m.energy)
+}
+
+// currents implements the api.MeterCurrent interface
+func (m *Obis) currents() (float64, float64, float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l1, err := m.get(obis.CurrentL1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	// single phase meters only provide l1
+	return l1, m.readings[obis.CurrentL2], m.readings[obis.CurrentL3], nil
+}
@@ -0,0 +1,66 @@
+package obis
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grid-x/serial"
+)
+
+// Open connects to the IR head either by serial device or by TCP uri (e.g. ser2net).
+// Reads fail if no data is received within timeout.
+func Open(uri, device string, baudrate int, comset string, timeout time.Duration) (io.ReadCloser, error) {
+	if (uri == "") == (device == "") {
+		return nil, errors.New("need either uri or device")
+	}
+
+	if uri != "" {
+		conn, err := net.DialTimeout("tcp", uri, timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		return &deadlineConn{Conn: conn, timeout: timeout}, nil
+	}
+
+	if len(comset) != 3 {
+		return nil, fmt.Errorf("invalid comset: %s", comset)
+	}
+
+	dataBits, err := strconv.Atoi(comset[:1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid comset: %s", comset)
+	}
+
+	stopBits, err := strconv.Atoi(comset[2:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid comset: %s", comset)
+	}
+
+	return serial.Open(&serial.Config{
+		Address:  device,
+		BaudRate: baudrate,
+		DataBits: dataBits,
+		StopBits: stopBits,
+		Parity:   strings.ToUpper(comset[1:2]),
+		Timeout:  timeout,
+	})
+}
+
+// deadlineConn is a net.Conn applying the read timeout to each read
+type deadlineConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+func (c *deadlineConn) Read(b []byte) (int, error) {
+	if err := c.Conn.SetReadDeadline(time.Now().Add(c.timeout)); err != nil {
+		return 0, err
+	}
+	return c.Conn.Read(b)
+}
@@ -0,0 +1,87 @@
+package obis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// D0 decodes IEC 62056-21 telegrams as pushed by meters in mode D. Mode C meters requiring a request are not supported:
+//
+//	/EBZ5DD32R06ETA_107
+//
+//	1-0:1.8.0*255(000123.4567*kWh)
+//	1-0:16.7.0*255(000456.78*W)
+//	!
+type D0 struct {
+	r *bufio.Reader
+}
+
+// NewD0Decoder creates a D0 decoder reading from r
+func NewD0Decoder(r io.Reader) *D0 {
+	return &D0{r: bufio.NewReader(r)}
+}
+
+var d0LineRE = regexp.MustCompile(`^([0-9A-Za-z\-:\.\*&]+)\(([^*)]*)(?:\*([^)]*))?\)`)
+
+// Decode implements the Decoder interface
+func (d *D0) Decode() ([]Reading, error) {
+	var started bool
+	var res []Reading
+
+	for {
+		line, err := d.r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimSpace(line)
+
+		switch {
+		// identification line starts the telegram
+		case strings.HasPrefix(line, "/"):
+			started = true
+			res = nil
+
+		case !started:
+			continue
+
+		// end of telegram
+		case strings.HasPrefix(line, "!"):
+			if len(res) == 0 {
+				return nil, fmt.Errorf("%w: no values", ErrInvalid)
+			}
+			return res, nil
+
+		default:
+			if r, ok := d0Reading(line); ok {
+				res = append(res, r)
+			}
+		}
+	}
+}
+
+// d0Reading parses a data line. Lines without numeric value are skipped.
+func d0Reading(line string) (Reading, bool) {
+	match := d0LineRE.FindStringSubmatch(line)
+	if match == nil {
+		return Reading{}, false
+	}
+
+	code, err := Normalize(match[1])
+	if err != nil {
+		return Reading{}, false
+	}
+
+	val, err := strconv.ParseFloat(match[2], 64)
+	if err != nil {
+		return Reading{}, false
+	}
+
+	val, unit := normalizeUnit(val, match[3])
+
+	return Reading{Code: code, Value: val, Unit: unit}, true
+}
@@ -0,0 +1,79 @@
+package obis
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestD0(t *testing.T) {
+	telegram := "" +
+		"1-0:1.8.0*255(000001.0000*kWh)\r\n" + // incomplete telegram
+		"!\r\n" +
+		"/EBZ5DD32R06ETA_107\r\n" +
+		"\r\n" +
+		"1-0:0.0.0*255(1EBZ0100507409)\r\n" +
+		"1-0:96.1.0*255(1EBZ0100507409)\r\n" +
+		"1-0:1.8.0*255(000123.4567*kWh)\r\n" +
+		"1-0:2.8.0*255(000010.0000*kWh)\r\n" +
+		"1-0:16.7.0*255(000456.78*W)\r\n" +
+		"1-0:32.7.0*255(232.1*V)\r\n" +
+		"1-0:96.5.0*255(001C0104)\r\n" +
+		"0-0:96.8.0*255(00A8B2A4)\r\n" +
+		"!\r\n" +
+		"/ISk5MT174-0001\r\n" +
+		"\r\n" +
+		"1.8.0(0012345.6*Wh)\r\n" +
+		"1.7.0(1.5*kW)\r\n" +
+		"!\r\n"
+
+	dec := NewD0Decoder(strings.NewReader(telegram))
+
+	for _, expected := range [][]Reading{
+		{
+			{Code: Import, Value: 123.4567, Unit: "kWh"},
+			{Code: Export, Value: 10, Unit: "kWh"},
+			{Code: Power, Value: 456.78, Unit: "W"},
+			{Code: VoltageL1, Value: 232.1, Unit: "V"},
+		},
+		{
+			{Code: Import, Value: 12.3456, Unit: "kWh"},
+			{Code: PowerImport, Value: 1500, Unit: "W"},
+		},
+	} {
+		res, err := dec.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// identifiers and hex status values are skipped
+		if len(res) != len(expected) {
+			t.Fatalf("expected %d readings, got %v", len(expected), res)
+		}
+
+		for i, r := range res {
+			if e := expected[i]; r.Code != e.Code || r.Unit != e.Unit || r.Value-e.Value > 1e-6 || e.Value-r.Value > 1e-6 {
+				t.Errorf("expected %v, got %v", e, r)
+			}
+		}
+	}
+
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for code, expected := range map[string]string{
+		"1-0:1.8.0*255": "1.8.0",
+		"1-0:16.7.0":    "16.7.0",
+		"01.08.00":      "1.8.0",
+		"1.8.1*01":      "1.8.1",
+		"C.1.0":         "",
+	} {
+		res, err := Normalize(code)
+		if res != expected || (expected == "") != (err != nil) {
+			t.Errorf("%s: expected %s, got %s %v", code, expected, res, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Package obis decodes smart meter telegrams read through an optical IR head.
+// Supported protocols are SML (Smart Message Language) and IEC 62056-21 D0.
+// Values are identified by their OBIS code.
+package obis
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Common OBIS codes in C.D.E notation
+const (
+	Power       = "16.7.0" // sum active power, import positive
+	PowerImport = "1.7.0"  // active power import
+	PowerExport = "2.7.0"  // active power export
+	Import      = "1.8.0"  // active energy import
+	Export      = "2.8.0"  // active energy export
+	CurrentL1   = "31.7.0"
+	CurrentL2   = "51.7.0"
+	CurrentL3   = "71.7.0"
+	VoltageL1   = "32.7.0"
+	VoltageL2   = "52.7.0"
+	VoltageL3   = "72.7.0"
+)
+
+// ErrInvalid indicates a corrupted telegram. Decoding can continue with the next telegram.
+var ErrInvalid = errors.New("invalid telegram")
+
+// Reading is a single value of a telegram. Energy is normalized to kWh and power to W.
+type Reading struct {
+	Code  string // C.D.E
+	Value float64
+	Unit  string
+}
+
+// Decoder returns the readings of the next telegram from the underlying stream
+type Decoder interface {
+	Decode() ([]Reading, error)
+}
+
+var codeRE = regexp.MustCompile(`^(?:(\d+)-(\d+):)?(\d+)\.(\d+)\.(\d+)(?:[*&](\d+))?$`)
+
+// Normalize converts an OBIS code given as A-B:C.D.E*F or C.D.E to C.D.E notation
+func Normalize(code string) (string, error) {
+	match := codeRE.FindStringSubmatch(code)
+	if match == nil {
+		return "", fmt.Errorf("invalid obis code: %s", code)
+	}
+
+	return fmt.Sprintf("%s.%s.%s", trim(match[3]), trim(match[4]), trim(match[5])), nil
+}
+
+// trim removes leading zeros from numeric code groups
+func trim(s string) string {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return s
+	}
+	return strconv.Itoa(i)
+}
+
+// normalizeUnit converts energy to kWh and power to W
+func normalizeUnit(val float64, unit string) (float64, string) {
+	switch unit {
+	case "Wh":
+		return val / 1e3, "kWh"
+	case "kW":
+		return val * 1e3, "W"
+	}
+	return val, unit
+}
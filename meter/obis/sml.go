@@ -0,0 +1,345 @@
+package obis
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+var (
+	smlEscape = []byte{0x1b, 0x1b, 0x1b, 0x1b}
+	smlStart  = []byte{0x01, 0x01, 0x01, 0x01}
+)
+
+const (
+	smlEnd        = 0x1a
+	smlGetListRes = 0x0701
+)
+
+// SML units (DLMS unit codes)
+var smlUnits = map[uint8]string{
+	27: "W",
+	28: "VA",
+	29: "var",
+	30: "Wh",
+	33: "A",
+	35: "V",
+	44: "Hz",
+}
+
+// SML decodes SML transport frames and returns the values of contained GetList responses
+type SML struct {
+	r *bufio.Reader
+}
+
+// NewSMLDecoder creates an SML decoder reading from r
+func NewSMLDecoder(r io.Reader) *SML {
+	return &SML{r: bufio.NewReader(r)}
+}
+
+// Decode implements the Decoder interface
+func (d *SML) Decode() ([]Reading, error) {
+	frame, err := d.frame()
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := smlParse(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Reading
+	for _, msg := range msgs {
+		res = append(res, smlReadings(msg)...)
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: no values", ErrInvalid)
+	}
+
+	return res, nil
+}
+
+// sync reads until the start escape sequence
+func (d *SML) sync() error {
+	var win [8]byte
+
+	for {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		copy(win[:], win[1:])
+		win[7] = b
+
+		if bytes.Equal(win[:4], smlEscape) && bytes.Equal(win[4:], smlStart) {
+			return nil
+		}
+	}
+}
+
+// frame reads the next transport frame and returns the unescaped message data
+func (d *SML) frame() ([]byte, error) {
+	if err := d.sync(); err != nil {
+		return nil, err
+	}
+
+	crc := crc16(0xffff, smlEscape)
+	crc = crc16(crc, smlStart)
+
+	var data []byte
+	var chunk [4]byte
+
+	for {
+		if _, err := io.ReadFull(d.r, chunk[:]); err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(chunk[:], smlEscape) {
+			crc = crc16(crc, chunk[:])
+			data = append(data, chunk[:]...)
+			continue
+		}
+
+		var esc [4]byte
+		if _, err := io.ReadFull(d.r, esc[:]); err != nil {
+			return nil, err
+		}
+
+		switch {
+		// escaped escape sequence
+		case bytes.Equal(esc[:], smlEscape):
+			crc = crc16(crc, smlEscape)
+			crc = crc16(crc, smlEscape)
+			data = append(data, smlEscape...)
+
+		// end of frame with padding and checksum
+		case esc[0] == smlEnd:
+			crc = crc16(crc, smlEscape)
+			crc = crc16(crc, esc[:2])
+
+			padding := int(esc[1])
+			if padding > len(data) {
+				return nil, fmt.Errorf("%w: padding", ErrInvalid)
+			}
+
+			if expected := binary.BigEndian.Uint16(esc[2:]); crc^0xffff != expected && swap(crc^0xffff) != expected {
+				return nil, fmt.Errorf("%w: checksum", ErrInvalid)
+			}
+
+			return data[:len(data)-padding], nil
+
+		default:
+			return nil, fmt.Errorf("%w: escape sequence % x", ErrInvalid, esc)
+		}
+	}
+}
+
+// swap swaps the checksum bytes as some meters transmit little endian
+func swap(crc uint16) uint16 {
+	return crc<<8 | crc>>8
+}
+
+// crc16 implements CRC-16/X-25
+func crc16(crc uint16, b []byte) uint16 {
+	for _, c := range b {
+		crc ^= uint16(c)
+		for i := 0; i < 8; i++ {
+			if crc&1 > 0 {
+				crc = crc>>1 ^ 0x8408
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
+
+// smlParse decodes all messages of the frame into nested lists
+func smlParse(b []byte) ([][]interface{}, error) {
+	var res [][]interface{}
+
+	for len(b) > 0 {
+		// end of message and padding
+		if b[0] == 0x00 {
+			b = b[1:]
+			continue
+		}
+
+		val, n, err := smlValue(b)
+		if err != nil {
+			return nil, err
+		}
+		b = b[n:]
+
+		msg, ok := val.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: message is not a list", ErrInvalid)
+		}
+
+		res = append(res, msg)
+	}
+
+	return res, nil
+}
+
+// smlValue decodes a single type-length-value element and returns the number of bytes consumed
+func smlValue(b []byte) (interface{}, int, error) {
+	if len(b) == 0 {
+		return nil, 0, fmt.Errorf("%w: unexpected end", ErrInvalid)
+	}
+
+	// end of message
+	if b[0] == 0x00 {
+		return nil, 1, nil
+	}
+
+	typ := b[0] & 0x70
+	length := int(b[0] & 0x0f)
+	tl := 1
+
+	for b[tl-1]&0x80 > 0 {
+		if tl >= len(b) {
+			return nil, 0, fmt.Errorf("%w: unexpected end", ErrInvalid)
+		}
+		length = length<<4 | int(b[tl]&0x0f)
+		tl++
+	}
+
+	// list length counts elements
+	if typ == 0x70 {
+		n := tl
+		list := make([]interface{}, 0, length)
+
+		for i := 0; i < length; i++ {
+			val, m, err := smlValue(b[n:])
+			if err != nil {
+				return nil, 0, err
+			}
+			list = append(list, val)
+			n += m
+		}
+
+		return list, n, nil
+	}
+
+	// other lengths include the type-length bytes
+	if length < tl || length > len(b) {
+		return nil, 0, fmt.Errorf("%w: invalid length", ErrInvalid)
+	}
+
+	data := b[tl:length]
+
+	switch typ {
+	case 0x00:
+		// optional value not set
+		if len(data) == 0 {
+			return nil, length, nil
+		}
+		return data, length, nil
+
+	case 0x40:
+		return len(data) > 0 && data[0] > 0, length, nil
+
+	case 0x50:
+		var v int64
+		for i, c := range data {
+			if i == 0 {
+				v = int64(int8(c))
+			} else {
+				v = v<<8 | int64(c)
+			}
+		}
+		return v, length, nil
+
+	case 0x60:
+		var v uint64
+		for _, c := range data {
+			v = v<<8 | uint64(c)
+		}
+		return v, length, nil
+
+	default:
+		return nil, 0, fmt.Errorf("%w: type %02x", ErrInvalid, b[0])
+	}
+}
+
+// smlReadings extracts the values of a GetList response message
+func smlReadings(msg []interface{}) []Reading {
+	if len(msg) < 4 {
+		return nil
+	}
+
+	body, ok := msg[3].([]interface{})
+	if !ok || len(body) != 2 {
+		return nil
+	}
+
+	if tag, ok := body[0].(uint64); !ok || tag != smlGetListRes {
+		return nil
+	}
+
+	list, ok := body[1].([]interface{})
+	if !ok || len(list) < 5 {
+		return nil
+	}
+
+	entries, ok := list[4].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var res []Reading
+	for _, e := range entries {
+		if r, ok := smlReading(e); ok {
+			res = append(res, r)
+		}
+	}
+
+	return res
+}
+
+// smlReading converts a list entry of objName, status, valTime, unit, scaler, value, valueSignature
+func smlReading(e interface{}) (Reading, bool) {
+	entry, ok := e.([]interface{})
+	if !ok || len(entry) < 6 {
+		return Reading{}, false
+	}
+
+	name, ok := entry[0].([]byte)
+	if !ok || len(name) != 6 {
+		return Reading{}, false
+	}
+
+	var val float64
+	switch v := entry[5].(type) {
+	case int64:
+		val = float64(v)
+	case uint64:
+		val = float64(v)
+	default:
+		return Reading{}, false
+	}
+
+	if scaler, ok := entry[4].(int64); ok {
+		val *= math.Pow10(int(scaler))
+	}
+
+	var unit string
+	if u, ok := entry[3].(uint64); ok {
+		unit = smlUnits[uint8(u)]
+	}
+
+	val, unit = normalizeUnit(val, unit)
+
+	return Reading{
+		Code:  fmt.Sprintf("%d.%d.%d", name[2], name[3], name[4]),
+		Value: val,
+		Unit:  unit,
+	}, true
+}
@@ -0,0 +1,159 @@
+package obis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+func smlList(elems ...[]byte) []byte {
+	res := []byte{0x70 | byte(len(elems))}
+	for _, e := range elems {
+		res = append(res, e...)
+	}
+	return res
+}
+
+func smlOctet(b ...byte) []byte {
+	return append([]byte{byte(len(b) + 1)}, b...)
+}
+
+func smlUint(v uint64, size int) []byte {
+	res := []byte{0x60 | byte(size+1)}
+	for i := size - 1; i >= 0; i-- {
+		res = append(res, byte(v>>(8*i)))
+	}
+	return res
+}
+
+func smlInt(v int64, size int) []byte {
+	res := smlUint(uint64(v), size)
+	res[0] = 0x50 | byte(size+1)
+	return res
+}
+
+var smlOptional = []byte{0x01}
+
+func smlEntry(code []byte, unit uint8, scaler int8, value []byte) []byte {
+	return smlList(
+		smlOctet(code...),
+		smlOptional,
+		smlOptional,
+		smlUint(uint64(unit), 1),
+		smlInt(int64(scaler), 1),
+		value,
+		smlOptional,
+	)
+}
+
+func smlMessage(tag uint64, body []byte) []byte {
+	return append(smlList(
+		smlOctet(0x01, 0x02),
+		smlUint(0, 1),
+		smlUint(0, 1),
+		smlList(smlUint(tag, 2), body),
+		smlUint(0, 2),
+		[]byte{},
+	), 0x00)
+}
+
+// smlFrame wraps messages into an escaped and padded transport frame
+func smlFrame(swapCRC bool, msgs ...[]byte) []byte {
+	var data []byte
+	for _, msg := range msgs {
+		data = append(data, msg...)
+	}
+
+	padding := (4 - len(data)%4) % 4
+	data = append(data, make([]byte, padding)...)
+
+	var escaped []byte
+	for i := 0; i < len(data); i += 4 {
+		escaped = append(escaped, data[i:i+4]...)
+		if bytes.Equal(data[i:i+4], smlEscape) {
+			escaped = append(escaped, smlEscape...)
+		}
+	}
+
+	res := append(append(append([]byte{}, smlEscape...), smlStart...), escaped...)
+	res = append(res, smlEscape...)
+	res = append(res, smlEnd, byte(padding))
+
+	crc := crc16(0xffff, res) ^ 0xffff
+	if swapCRC {
+		crc = swap(crc)
+	}
+
+	return append(res, byte(crc>>8), byte(crc))
+}
+
+func smlGetList(entries ...[]byte) []byte {
+	return smlMessage(smlGetListRes, smlList(
+		smlOptional,
+		smlOctet(0x0a, 0x01, 0x45, 0x4d, 0x48),
+		smlOptional,
+		smlOptional,
+		smlList(entries...),
+		smlOptional,
+		smlOptional,
+	))
+}
+
+func TestSML(t *testing.T) {
+	openRes := smlMessage(0x0101, smlList(smlOptional, smlOptional, smlOctet(0x01), smlOctet(0x02), smlOptional, smlOptional))
+
+	getList := smlGetList(
+		smlEntry([]byte{1, 0, 1, 8, 0, 255}, 30, -1, smlUint(123456789, 8)),
+		smlEntry([]byte{1, 0, 2, 8, 0, 255}, 30, 0, smlUint(4321000, 4)),
+		smlEntry([]byte{1, 0, 16, 7, 0, 255}, 27, 0, smlInt(-1234, 4)),
+		smlEntry([]byte{1, 0, 31, 7, 0, 255}, 33, -2, smlUint(1250, 2)),
+		// escape sequence within value
+		smlEntry([]byte{1, 0, 32, 7, 0, 255}, 35, -1, smlUint(0x1b1b1b1b, 4)),
+	)
+
+	// garbage before frame start
+	stream := append([]byte{0x00, 0x1b, 0x1b, 0x42}, smlFrame(false, openRes, getList)...)
+	stream = append(stream, smlFrame(true, getList)...)
+
+	dec := NewSMLDecoder(bytes.NewReader(stream))
+
+	for i := 0; i < 2; i++ {
+		res, err := dec.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []Reading{
+			{Code: Import, Value: 12345.6789, Unit: "kWh"},
+			{Code: Export, Value: 4321, Unit: "kWh"},
+			{Code: Power, Value: -1234, Unit: "W"},
+			{Code: CurrentL1, Value: 12.5, Unit: "A"},
+			{Code: VoltageL1, Value: 0x1b1b1b1b / 10.0, Unit: "V"},
+		}
+
+		if len(res) != len(expected) {
+			t.Fatalf("expected %d readings, got %v", len(expected), res)
+		}
+
+		for j, r := range res {
+			if e := expected[j]; r.Code != e.Code || r.Unit != e.Unit || r.Value-e.Value > 1e-6 || e.Value-r.Value > 1e-6 {
+				t.Errorf("expected %v, got %v", e, r)
+			}
+		}
+	}
+
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("expected EOF, got %v", err)
+	}
+}
+
+func TestSMLChecksum(t *testing.T) {
+	frame := smlFrame(false, smlGetList(smlEntry([]byte{1, 0, 16, 7, 0, 255}, 27, 0, smlInt(100, 2))))
+	binary.BigEndian.PutUint16(frame[len(frame)-2:], 0)
+
+	if _, err := NewSMLDecoder(bytes.NewReader(frame)).Decode(); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected invalid checksum, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package meter
+
+// Code generated by github.com/andig/cmd/tools/decorate.go. DO NOT EDIT.
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+func decorateObis(base api.Meter, meterEnergy func() (float64, error), meterCurrent func() (float64, float64, float64, error)) api.Meter {
+	switch {
+	case meterCurrent == nil && meterEnergy == nil:
+		return base
+
+	case meterCurrent == nil && meterEnergy != nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+		}{
+			Meter: base,
+			MeterEnergy: &decorateObisMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+
+	case meterCurrent != nil && meterEnergy == nil:
+		return &struct {
+			api.Meter
+			api.MeterCurrent
+		}{
+			Meter: base,
+			MeterCurrent: &decorateObisMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+		}
+
+	case meterCurrent != nil && meterEnergy != nil:
+		return &struct {
+			api.Meter
+			api.MeterCurrent
+			api.MeterEnergy
+		}{
+			Meter: base,
+			MeterCurrent: &decorateObisMeterCurrentImpl{
+				meterCurrent: meterCurrent,
+			},
+			MeterEnergy: &decorateObisMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+		}
+	}
+
+	return nil
+}
+
+type decorateObisMeterCurrentImpl struct {
+	meterCurrent func() (float64, float64, float64, error)
+}
+
+func (impl *decorateObisMeterCurrentImpl) Currents() (float64, float64, float64, error) {
+	return impl.meterCurrent()
+}
+
+type decorateObisMeterEnergyImpl struct {
+	meterEnergy func() (float64, error)
+}
+
+func (impl *decorateObisMeterEnergyImpl) TotalEnergy() (float64, error) {
+	return impl.meterEnergy()
+}
@@ -0,0 +1,42 @@
+package meter
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/meter/obis"
+)
+
+type obisConn struct {
+	*io.PipeReader
+	closed chan struct{}
+}
+
+func (c *obisConn) Close() error {
+	close(c.closed)
+	return c.PipeReader.Close()
+}
+
+func TestObisTimeout(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	conn := &obisConn{PipeReader: r, closed: make(chan struct{})}
+
+	open := func() (io.ReadCloser, error) {
+		return conn, nil
+	}
+
+	decoder := func(r io.Reader) obis.Decoder { return obis.NewD0Decoder(r) }
+
+	if _, err := NewObis(open, decoder, "", obis.Import, 10*time.Millisecond); err == nil {
+		t.Fatal("expected timeout")
+	}
+
+	select {
+	case <-conn.closed:
+	case <-time.After(time.Second):
+		t.Error("connection not closed")
+	}
+}
@@ -0,0 +1,104 @@
+package meter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/simulator"
+)
+
+// simulator meter usages
+const (
+	simulatorGrid   = "grid"
+	simulatorPV     = "pv"
+	simulatorCharge = "charge"
+)
+
+// Simulator is a simulated grid, pv or charge meter
+type Simulator struct {
+	sim   *simulator.Simulation
+	usage string
+}
+
+func init() {
+	registry.Add("simulator", NewSimulatorFromConfig)
+}
+
+// NewSimulatorFromConfig creates a simulated meter from generic config
+func NewSimulatorFromConfig(other map[string]interface{}) (api.Meter, error) {
+	pv := simulator.DefaultPVConfig()
+
+	cc := struct {
+		Simulation      string
+		Usage           string
+		Peak            float64       // pv
+		Sunrise, Sunset time.Duration // pv
+		HomePower       float64       // grid
+	}{
+		Sunrise:   pv.Sunrise,
+		Sunset:    pv.Sunset,
+		HomePower: simulator.DefaultHomePower,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	m := &Simulator{
+		sim:   simulator.Instance(cc.Simulation),
+		usage: strings.ToLower(cc.Usage),
+	}
+
+	switch m.usage {
+	case simulatorGrid:
+		m.sim.ConfigureHome(cc.HomePower)
+
+	case simulatorPV:
+		if err := m.sim.ConfigurePV(simulator.PVConfig{
+			Peak:    cc.Peak,
+			Sunrise: cc.Sunrise,
+			Sunset:  cc.Sunset,
+		}); err != nil {
+			return nil, err
+		}
+
+	case simulatorCharge:
+
+	default:
+		return nil, fmt.Errorf("invalid usage: %s", cc.Usage)
+	}
+
+	return m, nil
+}
+
+// CurrentPower implements the api.Meter interface
+func (m *Simulator) CurrentPower() (float64, error) {
+	switch m.usage {
+	case simulatorGrid:
+		return m.sim.GridPower(), nil
+	case simulatorPV:
+		return m.sim.PVPower(), nil
+	default:
+		return m.sim.ChargePower() - m.sim.DischargePower(), nil
+	}
+}
+
+var _ api.MeterCurrent = (*Simulator)(nil)
+
+// Currents implements the api.MeterCurrent interface
+func (m *Simulator) Currents() (float64, float64, float64, error) {
+	if m.usage == simulatorCharge {
+		i1, i2, i3 := m.sim.Currents()
+		return i1, i2, i3, nil
+	}
+
+	// distribute power evenly across phases
+	power, _ := m.CurrentPower()
+	i := math.Round(10*power/3/m.sim.Voltage()) / 10
+
+	return i, i, i, nil
+}
@@ -0,0 +1,339 @@
+package meter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sunspec "github.com/andig/gosunspec"
+	sunspecbus "github.com/andig/gosunspec/modbus"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/modbus"
+)
+
+/*
+The sunspec meter scans the SunSpec model chain of a device and configures itself from the available models:
+
+- grid    ... meter models 201-204 (power, import energy, currents)
+- pv      ... inverter models 101-103 and 111-113 (power, energy, currents)
+- battery ... storage model 802 (power, soc), soc from model 124 if 802 has none
+
+If usage is not configured, grid is used if the device provides a meter model, pv for inverters and battery for storage devices.
+Battery power requires model 802. Inverter power of hybrid inverters includes pv and has no charge/discharge sign.
+
+** Example configuration **
+meters:
+- name: grid
+  type: sunspec
+  uri: 192.168.0.10:502
+  id: 240
+- name: battery
+  type: sunspec
+  uri: 192.168.0.10:502
+  id: 1
+  usage: battery
+*/
+
+// SunSpec is an api.Meter implementation for SunSpec devices with automatic model discovery
+type SunSpec struct {
+	log      *util.Logger
+	mu       sync.Mutex
+	usage    string
+	power    sunspecPoint
+	energy   sunspecPoint
+	currents []sunspecPoint
+	soc      sunspecPoint
+}
+
+// sunspecPoint is a point of a specific model
+type sunspecPoint struct {
+	model sunspec.Model
+	id    string
+}
+
+// valid returns true if the point is defined
+func (p sunspecPoint) valid() bool {
+	return p.model != nil
+}
+
+// sunspec model groups in order of preference
+var (
+	sunspecMeterModels    = []int{203, 204, 201, 202}
+	sunspecInverterModels = []int{103, 113, 102, 112, 101, 111}
+	sunspecStorageModels  = []int{802} // model 124 has no battery power
+)
+
+func init() {
+	registry.Add("sunspec", NewSunSpecFromConfig)
+}
+
+//go:generate go run ../cmd/tools/decorate.go -f decorateSunSpec -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.MeterCurrent,Currents,func() (float64, float64, float64, error)" -t "api.Battery,SoC,func() (float64, error)"
+
+// NewSunSpecFromConfig creates a SunSpec meter from generic config
+func NewSunSpecFromConfig(other map[string]interface{}) (api.Meter, error) {
+	cc := struct {
+		modbus.Settings `mapstructure:",squash"`
+		Usage           string
+		Timeout         time.Duration
+	}{
+		Settings: modbus.Settings{
+			ID: 1,
+		},
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	format := modbus.TcpFormat
+	if cc.RTU != nil && *cc.RTU {
+		format = modbus.RtuFormat
+	}
+
+	conn, err := modbus.NewConnection(cc.URI, cc.Device, cc.Comset, cc.Baudrate, format, cc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// set non-default timeout
+	if cc.Timeout > 0 {
+		conn.Timeout(cc.Timeout)
+	}
+
+	log := util.NewLogger("sunspec")
+	conn.Logger(log.TRACE)
+
+	return NewSunSpec(log, conn, cc.SubDevice, cc.Usage)
+}
+
+// NewSunSpec creates a SunSpec meter by scanning the device's model chain
+func NewSunSpec(log *util.Logger, conn *modbus.Connection, subdevice int, usage string) (api.Meter, error) {
+	in, err := sunspecbus.Open(conn)
+	if in == nil {
+		return nil, fmt.Errorf("sunspec: %w", err)
+	}
+
+	// partially opened devices can still provide the relevant models
+	if err != nil {
+		log.DEBUG.Printf("partially opened: %v", err)
+	}
+
+	devices := in.Collect(sunspec.AllDevices)
+	if len(devices) <= subdevice {
+		return nil, fmt.Errorf("sunspec: subdevice %d not found", subdevice)
+	}
+
+	models := make(map[int]sunspec.Model)
+	for _, model := range devices[subdevice].Collect(sunspec.AllModels) {
+		if _, ok := models[int(model.Id())]; !ok {
+			models[int(model.Id())] = model
+		}
+	}
+
+	ids := make([]int, 0, len(models))
+	for id := range models {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	usage, err = sunspecUsage(ids, usage)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &SunSpec{
+		log:   log,
+		usage: usage,
+	}
+
+	// point of the first available model, if the model defines the point
+	point := func(candidates []int, id string) sunspecPoint {
+		if model, ok := models[sunspecFirst(ids, candidates)]; ok {
+			if block, err := model.Block(0); err == nil {
+				if _, err := block.Point(id); err == nil {
+					return sunspecPoint{model: model, id: id}
+				}
+			}
+		}
+		return sunspecPoint{}
+	}
+
+	switch usage {
+	case "grid":
+		m.power = point(sunspecMeterModels, "W")
+		m.energy = point(sunspecMeterModels, "TotWhImp")
+		m.currents = []sunspecPoint{
+			point(sunspecMeterModels, "AphA"),
+			point(sunspecMeterModels, "AphB"),
+			point(sunspecMeterModels, "AphC"),
+		}
+
+	case "pv":
+		m.power = point(sunspecInverterModels, "W")
+		m.energy = point(sunspecInverterModels, "WH")
+		m.currents = []sunspecPoint{
+			point(sunspecInverterModels, "AphA"),
+			point(sunspecInverterModels, "AphB"),
+			point(sunspecInverterModels, "AphC"),
+		}
+
+	case "battery":
+		m.power = point([]int{802}, "W")
+		if m.soc = point([]int{802}, "SoC"); !m.soc.valid() {
+			m.soc = point([]int{124}, "ChaState")
+		}
+	}
+
+	if !m.power.valid() {
+		return nil, fmt.Errorf("sunspec: no power reading for %s in models %v", usage, ids)
+	}
+
+	log.DEBUG.Printf("models %v, usage %s", ids, usage)
+
+	var totalEnergy func() (float64, error)
+	if m.energy.valid() {
+		totalEnergy = m.totalEnergy
+	}
+
+	var currents func() (float64, float64, float64, error)
+	if len(m.currents) > 0 && m.currents[0].valid() {
+		currents = m.phaseCurrents
+	}
+
+	var soc func() (float64, error)
+	if m.soc.valid() {
+		soc = m.batterySoC
+	}
+
+	return decorateSunSpec(m, totalEnergy, currents, soc), nil
+}
+
+// sunspecFirst returns the first candidate model available in ids or zero
+func sunspecFirst(ids, candidates []int) int {
+	for _, c := range candidates {
+		for _, id := range ids {
+			if id == c {
+				return c
+			}
+		}
+	}
+	return 0
+}
+
+// sunspecUsage validates or determines the meter usage from the available models
+func sunspecUsage(ids []int, usage string) (string, error) {
+	meter := sunspecFirst(ids, sunspecMeterModels) > 0
+	inverter := sunspecFirst(ids, sunspecInverterModels) > 0
+	storage := sunspecFirst(ids, sunspecStorageModels) > 0
+
+	switch usage = strings.ToLower(usage); usage {
+	case "":
+		switch {
+		case meter:
+			return "grid", nil
+		case inverter:
+			return "pv", nil
+		case storage:
+			return "battery", nil
+		default:
+			return "", fmt.Errorf("sunspec: no supported model found in %v", ids)
+		}
+
+	case "grid":
+		if !meter {
+			return "", fmt.Errorf("sunspec: no meter model found in %v", ids)
+		}
+
+	case "pv":
+		if !inverter {
+			return "", fmt.Errorf("sunspec: no inverter model found in %v", ids)
+		}
+
+	case "battery":
+		if !storage {
+			return "", fmt.Errorf("sunspec: no storage model 802 found in %v", ids)
+		}
+
+	default:
+		return "", fmt.Errorf("invalid usage: %s", usage)
+	}
+
+	return usage, nil
+}
+
+// read reads the point's block and returns the scaled value. Unimplemented values are treated as zero.
+func (m *SunSpec) read(p sunspecPoint) (float64, error) {
+	if !p.valid() {
+		return 0, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	block, err := p.model.Block(0)
+	if err == nil {
+		err = block.Read()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	point, err := block.Point(p.id)
+	if err != nil {
+		return 0, err
+	}
+
+	if point.NotImplemented() {
+		return 0, nil
+	}
+
+	// scaled value panics on missing scale factor
+	var res float64
+	err = func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+		}()
+
+		res = point.ScaledValue()
+		return nil
+	}()
+
+	m.log.TRACE.Printf("%d:%s: %v", p.model.Id(), p.id, res)
+
+	return res, err
+}
+
+// CurrentPower implements the api.Meter interface
+func (m *SunSpec) CurrentPower() (float64, error) {
+	return m.read(m.power)
+}
+
+// totalEnergy implements the api.MeterEnergy interface
+func (m *SunSpec) totalEnergy() (float64, error) {
+	res, err := m.read(m.energy)
+	return res / 1e3, err
+}
+
+// phaseCurrents implements the api.MeterCurrent interface
+func (m *SunSpec) phaseCurrents() (float64, float64, float64, error) {
+	var res [3]float64
+
+	for i, p := range m.currents {
+		var err error
+		if res[i], err = m.read(p); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	return res[0], res[1], res[2], nil
+}
+
+// batterySoC implements the api.Battery interface
+func (m *SunSpec) batterySoC() (float64, error) {
+	return m.read(m.soc)
+}
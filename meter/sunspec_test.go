@@ -0,0 +1,45 @@
+package meter
+
+import "testing"
+
+func TestSunSpecUsage(t *testing.T) {
+	tc := []struct {
+		ids   []int
+		usage string
+		res   string
+		err   bool
+	}{
+		{[]int{1, 203}, "", "grid", false},
+		{[]int{1, 103, 120, 160}, "", "pv", false},
+		{[]int{1, 802}, "", "battery", false},
+		{[]int{1, 103, 124, 203}, "", "grid", false},
+		{[]int{1, 103, 124, 203}, "PV", "pv", false},
+		{[]int{1, 103, 802, 203}, "battery", "battery", false},
+		{[]int{1, 103, 124, 203}, "battery", "", true},
+		{[]int{1, 124}, "", "", true},
+		{[]int{1, 103}, "grid", "", true},
+		{[]int{1, 103}, "battery", "", true},
+		{[]int{1, 203}, "foo", "", true},
+		{[]int{1, 120}, "", "", true},
+	}
+
+	for _, tc := range tc {
+		res, err := sunspecUsage(tc.ids, tc.usage)
+		if (err != nil) != tc.err {
+			t.Errorf("%v %s: unexpected error %v", tc.ids, tc.usage, err)
+		}
+		if res != tc.res {
+			t.Errorf("%v %s: expected %s, got %s", tc.ids, tc.usage, tc.res, res)
+		}
+	}
+}
+
+func TestSunSpecFirst(t *testing.T) {
+	if id := sunspecFirst([]int{1, 101, 103}, sunspecInverterModels); id != 103 {
+		t.Errorf("expected 103, got %d", id)
+	}
+
+	if id := sunspecFirst([]int{1, 101}, sunspecMeterModels); id != 0 {
+		t.Errorf("expected 0, got %d", id)
+	}
+}
@@ -0,0 +1,20 @@
+package meter
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/templates"
+)
+
+func init() {
+	registry.Add("template", NewTemplateFromConfig)
+}
+
+// NewTemplateFromConfig creates a meter from a parameterized device template
+func NewTemplateFromConfig(other map[string]interface{}) (api.Meter, error) {
+	typ, conf, err := templates.Instantiate(templates.Meter, other)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFromConfig(typ, conf)
+}
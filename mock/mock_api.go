@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/evcc-io/evcc/api (interfaces: Charger,ChargeState,ChargePhases,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery)
+// Source: github.com/evcc-io/evcc/api (interfaces: Charger,ChargeState,ChargePhases,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,ChargerDischarge,VehiclePosition,VehicleChargeLimiter,VehicleStartCharge,VehicleClimateController)
 
 // Package mock is a generated GoMock package.
 package mock
@@ -437,3 +437,271 @@ func (mr *MockBatteryMockRecorder) SoC() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoC", reflect.TypeOf((*MockBattery)(nil).SoC))
 }
+
+// MockChargerDischarge is a mock of ChargerDischarge interface.
+type MockChargerDischarge struct {
+	ctrl     *gomock.Controller
+	recorder *MockChargerDischargeMockRecorder
+}
+
+// MockChargerDischargeMockRecorder is the mock recorder for MockChargerDischarge.
+type MockChargerDischargeMockRecorder struct {
+	mock *MockChargerDischarge
+}
+
+// NewMockChargerDischarge creates a new mock instance.
+func NewMockChargerDischarge(ctrl *gomock.Controller) *MockChargerDischarge {
+	mock := &MockChargerDischarge{ctrl: ctrl}
+	mock.recorder = &MockChargerDischargeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChargerDischarge) EXPECT() *MockChargerDischargeMockRecorder {
+	return m.recorder
+}
+
+// DischargePower mocks base method.
+func (m *MockChargerDischarge) DischargePower() (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DischargePower")
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DischargePower indicates an expected call of DischargePower.
+func (mr *MockChargerDischargeMockRecorder) DischargePower() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DischargePower", reflect.TypeOf((*MockChargerDischarge)(nil).DischargePower))
+}
+
+// MaxDischargeCurrent mocks base method.
+func (m *MockChargerDischarge) MaxDischargeCurrent(arg0 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxDischargeCurrent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MaxDischargeCurrent indicates an expected call of MaxDischargeCurrent.
+func (mr *MockChargerDischargeMockRecorder) MaxDischargeCurrent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxDischargeCurrent", reflect.TypeOf((*MockChargerDischarge)(nil).MaxDischargeCurrent), arg0)
+}
+
+// MockVehiclePosition is a mock of VehiclePosition interface.
+type MockVehiclePosition struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehiclePositionMockRecorder
+}
+
+// MockVehiclePositionMockRecorder is the mock recorder for MockVehiclePosition.
+type MockVehiclePositionMockRecorder struct {
+	mock *MockVehiclePosition
+}
+
+// NewMockVehiclePosition creates a new mock instance.
+func NewMockVehiclePosition(ctrl *gomock.Controller) *MockVehiclePosition {
+	mock := &MockVehiclePosition{ctrl: ctrl}
+	mock.recorder = &MockVehiclePositionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehiclePosition) EXPECT() *MockVehiclePositionMockRecorder {
+	return m.recorder
+}
+
+// Position mocks base method.
+func (m *MockVehiclePosition) Position() (float64, float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Position")
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Position indicates an expected call of Position.
+func (mr *MockVehiclePositionMockRecorder) Position() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Position", reflect.TypeOf((*MockVehiclePosition)(nil).Position))
+}
+
+// MockVehicleChargeLimiter is a mock of VehicleChargeLimiter interface.
+type MockVehicleChargeLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleChargeLimiterMockRecorder
+}
+
+// MockVehicleChargeLimiterMockRecorder is the mock recorder for MockVehicleChargeLimiter.
+type MockVehicleChargeLimiterMockRecorder struct {
+	mock *MockVehicleChargeLimiter
+}
+
+// NewMockVehicleChargeLimiter creates a new mock instance.
+func NewMockVehicleChargeLimiter(ctrl *gomock.Controller) *MockVehicleChargeLimiter {
+	mock := &MockVehicleChargeLimiter{ctrl: ctrl}
+	mock.recorder = &MockVehicleChargeLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleChargeLimiter) EXPECT() *MockVehicleChargeLimiterMockRecorder {
+	return m.recorder
+}
+
+// SetTargetSoC mocks base method.
+func (m *MockVehicleChargeLimiter) SetTargetSoC(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTargetSoC", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTargetSoC indicates an expected call of SetTargetSoC.
+func (mr *MockVehicleChargeLimiterMockRecorder) SetTargetSoC(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTargetSoC", reflect.TypeOf((*MockVehicleChargeLimiter)(nil).SetTargetSoC), arg0)
+}
+
+// TargetSoC mocks base method.
+func (m *MockVehicleChargeLimiter) TargetSoC() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TargetSoC")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TargetSoC indicates an expected call of TargetSoC.
+func (mr *MockVehicleChargeLimiterMockRecorder) TargetSoC() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TargetSoC", reflect.TypeOf((*MockVehicleChargeLimiter)(nil).TargetSoC))
+}
+
+// MockVehicleStartCharge is a mock of VehicleStartCharge interface.
+type MockVehicleStartCharge struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleStartChargeMockRecorder
+}
+
+// MockVehicleStartChargeMockRecorder is the mock recorder for MockVehicleStartCharge.
+type MockVehicleStartChargeMockRecorder struct {
+	mock *MockVehicleStartCharge
+}
+
+// NewMockVehicleStartCharge creates a new mock instance.
+func NewMockVehicleStartCharge(ctrl *gomock.Controller) *MockVehicleStartCharge {
+	mock := &MockVehicleStartCharge{ctrl: ctrl}
+	mock.recorder = &MockVehicleStartChargeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleStartCharge) EXPECT() *MockVehicleStartChargeMockRecorder {
+	return m.recorder
+}
+
+// StartCharge mocks base method.
+func (m *MockVehicleStartCharge) StartCharge() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCharge")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartCharge indicates an expected call of StartCharge.
+func (mr *MockVehicleStartChargeMockRecorder) StartCharge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCharge", reflect.TypeOf((*MockVehicleStartCharge)(nil).StartCharge))
+}
+
+// MockVehicleStopCharge is a mock of VehicleStopCharge interface.
+type MockVehicleStopCharge struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleStopChargeMockRecorder
+}
+
+// MockVehicleStopChargeMockRecorder is the mock recorder for MockVehicleStopCharge.
+type MockVehicleStopChargeMockRecorder struct {
+	mock *MockVehicleStopCharge
+}
+
+// NewMockVehicleStopCharge creates a new mock instance.
+func NewMockVehicleStopCharge(ctrl *gomock.Controller) *MockVehicleStopCharge {
+	mock := &MockVehicleStopCharge{ctrl: ctrl}
+	mock.recorder = &MockVehicleStopChargeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleStopCharge) EXPECT() *MockVehicleStopChargeMockRecorder {
+	return m.recorder
+}
+
+// StopCharge mocks base method.
+func (m *MockVehicleStopCharge) StopCharge() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopCharge")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopCharge indicates an expected call of StopCharge.
+func (mr *MockVehicleStopChargeMockRecorder) StopCharge() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopCharge", reflect.TypeOf((*MockVehicleStopCharge)(nil).StopCharge))
+}
+
+// MockVehicleClimateController is a mock of VehicleClimateController interface.
+type MockVehicleClimateController struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleClimateControllerMockRecorder
+}
+
+// MockVehicleClimateControllerMockRecorder is the mock recorder for MockVehicleClimateController.
+type MockVehicleClimateControllerMockRecorder struct {
+	mock *MockVehicleClimateController
+}
+
+// NewMockVehicleClimateController creates a new mock instance.
+func NewMockVehicleClimateController(ctrl *gomock.Controller) *MockVehicleClimateController {
+	mock := &MockVehicleClimateController{ctrl: ctrl}
+	mock.recorder = &MockVehicleClimateControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleClimateController) EXPECT() *MockVehicleClimateControllerMockRecorder {
+	return m.recorder
+}
+
+// StartClimate mocks base method.
+func (m *MockVehicleClimateController) StartClimate() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartClimate")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartClimate indicates an expected call of StartClimate.
+func (mr *MockVehicleClimateControllerMockRecorder) StartClimate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartClimate", reflect.TypeOf((*MockVehicleClimateController)(nil).StartClimate))
+}
+
+// StopClimate mocks base method.
+func (m *MockVehicleClimateController) StopClimate() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopClimate")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopClimate indicates an expected call of StopClimate.
+func (mr *MockVehicleClimateControllerMockRecorder) StopClimate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopClimate", reflect.TypeOf((*MockVehicleClimateController)(nil).StopClimate))
+}
@@ -25,6 +25,12 @@ type (
 	SetBoolProvider interface {
 		BoolSetter(param string) func(bool) error
 	}
+	SetFloatProvider interface {
+		FloatSetter(param string) func(float64) error
+	}
+	SetStringProvider interface {
+		StringSetter(param string) func(string) error
+	}
 )
 
 type providerRegistry map[string]func(map[string]interface{}) (IntProvider, error)
@@ -182,3 +188,41 @@ func NewBoolSetterFromConfig(param string, config Config) (res func(bool) error,
 
 	return
 }
+
+// NewFloatSetterFromConfig creates a FloatSetter from config
+func NewFloatSetterFromConfig(param string, config Config) (res func(float64) error, err error) {
+	factory, err := registry.Get(config.PluginType())
+	if err == nil {
+		var provider IntProvider
+		provider, err = factory(config.Other)
+
+		if prov, ok := provider.(SetFloatProvider); ok {
+			res = prov.FloatSetter(param)
+		}
+	}
+
+	if err == nil && res == nil {
+		err = fmt.Errorf("invalid plugin type: %s", config.PluginType())
+	}
+
+	return
+}
+
+// NewStringSetterFromConfig creates a StringSetter from config
+func NewStringSetterFromConfig(param string, config Config) (res func(string) error, err error) {
+	factory, err := registry.Get(config.PluginType())
+	if err == nil {
+		var provider IntProvider
+		provider, err = factory(config.Other)
+
+		if prov, ok := provider.(SetStringProvider); ok {
+			res = prov.StringSetter(param)
+		}
+	}
+
+	if err == nil && res == nil {
+		err = fmt.Errorf("invalid plugin type: %s", config.PluginType())
+	}
+
+	return
+}
@@ -0,0 +1,318 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/jq"
+	"github.com/fsnotify/fsnotify"
+	"github.com/itchyny/gojq"
+)
+
+// File implements file-based providers and setters
+type File struct {
+	mu      sync.Mutex
+	log     *util.Logger
+	clock   clock.Clock
+	path    string
+	payload string
+	scale   float64
+	cache   time.Duration
+	watched bool // file changes are notified, value stays valid until changed or cache expired
+	valid   bool
+	updated time.Time
+	val     string
+	err     error
+	re      *regexp.Regexp
+	jq      *gojq.Query
+}
+
+func init() {
+	registry.Add("file", NewFileProviderFromConfig)
+}
+
+// NewFileProviderFromConfig creates a file provider
+func NewFileProviderFromConfig(other map[string]interface{}) (IntProvider, error) {
+	cc := struct {
+		Path    string
+		Payload string // Payload only applies to setters
+		Regex   string
+		Jq      string
+		Scale   float64
+		Cache   time.Duration
+		Watch   bool
+	}{
+		Scale: 1,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	p, err := NewFileProvider(cc.Path, cc.Regex, cc.Jq, cc.Scale, cc.Cache)
+	if err != nil {
+		return nil, err
+	}
+
+	p.payload = cc.Payload
+
+	if cc.Watch {
+		if err := p.watch(); err != nil {
+			p.log.WARN.Printf("cannot watch %s, using cache instead: %v", p.path, err)
+		}
+	}
+
+	return p, nil
+}
+
+// NewFileProvider creates a file provider.
+// The file is read again once the cache duration has expired.
+func NewFileProvider(path, regex, jq string, scale float64, cache time.Duration) (*File, error) {
+	if path == "" {
+		return nil, errors.New("missing path")
+	}
+
+	p := &File{
+		log:   util.NewLogger("file"),
+		clock: clock.New(),
+		path:  filepath.Clean(path),
+		scale: scale,
+		cache: cache,
+	}
+
+	if regex != "" {
+		re, err := regexp.Compile(regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex '%s': %w", regex, err)
+		}
+
+		p.re = re
+	}
+
+	if jq != "" {
+		op, err := gojq.Parse(jq)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jq query '%s': %w", jq, err)
+		}
+
+		p.jq = op
+	}
+
+	return p, nil
+}
+
+// pseudoFS returns true for kernel pseudo filesystems which don't send change notifications
+func pseudoFS(path string) bool {
+	for _, dir := range []string{"/sys", "/proc"} {
+		if path == dir || strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// watch invalidates the cached value on file changes. The parent directory is
+// watched to also detect files being replaced or re-created.
+func (p *File) watch() error {
+	if pseudoFS(p.path) {
+		return errors.New("no change notifications for sysfs or procfs")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	if err := watcher.Add(filepath.Dir(p.path)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	p.mu.Lock()
+	p.watched = true
+	p.mu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if filepath.Clean(ev.Name) == p.path {
+					p.invalidate()
+				}
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				p.log.ERROR.Printf("watch %s: %v", p.path, err)
+				p.invalidate()
+			}
+		}
+	}()
+
+	return nil
+}
+
+// invalidate forces the file to be read on next access
+func (p *File) invalidate() {
+	p.mu.Lock()
+	p.valid = false
+	p.mu.Unlock()
+}
+
+// mustRead checks if the cached value needs to be updated
+func (p *File) mustRead() bool {
+	if !p.valid || p.err != nil {
+		return true
+	}
+
+	// cache duration limits the validity of watched files in case of missed notifications
+	if p.watched && p.cache == 0 {
+		return false
+	}
+
+	return p.clock.Since(p.updated) >= p.cache
+}
+
+// read reads the file and applies regex and jq queries
+func (p *File) read() (string, error) {
+	b, err := os.ReadFile(p.path)
+	if err != nil {
+		return "", err
+	}
+
+	if p.re != nil {
+		m := p.re.FindSubmatch(b)
+		switch {
+		case m == nil:
+			return "", fmt.Errorf("regex '%s' not matched", p.re)
+		case len(m) > 1:
+			b = m[1] // first submatch
+		default:
+			b = m[0]
+		}
+	}
+
+	if p.jq != nil {
+		v, err := jq.Query(p.jq, b)
+		return fmt.Sprintf("%v", v), err
+	}
+
+	s := strings.TrimSpace(string(b))
+	p.log.TRACE.Printf("%s: %s", p.path, s)
+
+	return s, nil
+}
+
+// StringGetter returns string from file contents
+func (p *File) StringGetter() func() (string, error) {
+	return func() (string, error) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		if p.mustRead() {
+			p.val, p.err = p.read()
+			p.updated = p.clock.Now()
+			p.valid = true
+		}
+
+		return p.val, p.err
+	}
+}
+
+// FloatGetter parses float from file contents
+func (p *File) FloatGetter() func() (float64, error) {
+	g := p.StringGetter()
+
+	return func() (float64, error) {
+		s, err := g()
+		if err != nil {
+			return 0, err
+		}
+
+		f, err := strconv.ParseFloat(s, 64)
+		return p.scale * f, err
+	}
+}
+
+// IntGetter parses int64 from file contents
+func (p *File) IntGetter() func() (int64, error) {
+	g := p.FloatGetter()
+
+	return func() (int64, error) {
+		f, err := g()
+		return int64(math.Round(f)), err
+	}
+}
+
+// BoolGetter parses bool from file contents. "on", "true" and 1 are considered truish.
+func (p *File) BoolGetter() func() (bool, error) {
+	g := p.StringGetter()
+
+	return func() (bool, error) {
+		s, err := g()
+		if err != nil {
+			return false, err
+		}
+
+		return util.Truish(s), nil
+	}
+}
+
+// set writes the formatted value to the file
+func (p *File) set(param string, val interface{}) error {
+	s, err := setFormattedValue(p.payload, param, val)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.valid = false
+
+	return os.WriteFile(p.path, []byte(s), 0644)
+}
+
+// IntSetter writes int value to file
+func (p *File) IntSetter(param string) func(int64) error {
+	return func(val int64) error {
+		return p.set(param, val)
+	}
+}
+
+// FloatSetter writes float value to file
+func (p *File) FloatSetter(param string) func(float64) error {
+	return func(val float64) error {
+		return p.set(param, val)
+	}
+}
+
+// StringSetter writes string value to file
+func (p *File) StringSetter(param string) func(string) error {
+	return func(val string) error {
+		return p.set(param, val)
+	}
+}
+
+// BoolSetter writes bool value to file
+func (p *File) BoolSetter(param string) func(bool) error {
+	return func(val bool) error {
+		return p.set(param, val)
+	}
+}
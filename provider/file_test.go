@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "value")
+
+	write := func(s string) {
+		if err := os.WriteFile(path, []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := NewFileProvider(path, "", ".power", 0.001, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clck := clock.NewMock()
+	p.clock = clck
+	g := p.FloatGetter()
+
+	if _, err := g(); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	expect := func(exp float64) {
+		t.Helper()
+		if f, err := g(); f != exp || err != nil {
+			t.Errorf("expected %.1f, got %.1f %v", exp, f, err)
+		}
+	}
+
+	write(`{"power": 1500}`)
+	expect(1.5)
+
+	// cached
+	write(`{"power": 2500}`)
+	expect(1.5)
+
+	clck.Add(time.Minute)
+	expect(2.5)
+
+	// setter invalidates cache
+	p.payload = `{"power": ${power}}`
+	if err := p.IntSetter("power")(500); err != nil {
+		t.Fatal(err)
+	}
+	expect(0.5)
+}
+
+func TestFileProviderRegex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "value")
+	if err := os.WriteFile(path, []byte("temp=42.5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := NewFileProvider(path, `temp=([\d.]+)`, "", 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := p.StringGetter()(); s != "42.5" || err != nil {
+		t.Errorf("unexpected result %s %v", s, err)
+	}
+
+	// no match
+	if err := os.WriteFile(path, []byte("hum=80\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := p.StringGetter()(); err == nil {
+		t.Errorf("expected error, got %s", s)
+	}
+
+	if _, err := NewFileProvider(path, `temp=(`, "", 1, 0); err == nil || !strings.Contains(err.Error(), "temp=(") {
+		t.Errorf("expected invalid regex error, got %v", err)
+	}
+}
+
+func TestFileProviderWatch(t *testing.T) {
+	p, err := NewFileProvider("/sys/class/gpio/gpio17/value", "", "", 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.watch(); err == nil {
+		t.Error("expected sysfs watch error")
+	}
+
+	// watched value expires after cache duration
+	p.cache = time.Minute
+	p.watched = true
+	p.valid = true
+
+	clck := clock.NewMock()
+	p.clock = clck
+	p.updated = clck.Now()
+
+	if p.mustRead() {
+		t.Error("unexpected read before cache expiry")
+	}
+
+	clck.Add(time.Minute)
+	if !p.mustRead() {
+		t.Error("expected read after cache expiry")
+	}
+}
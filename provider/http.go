@@ -236,6 +236,13 @@ func (p *HTTP) IntSetter(param string) func(int64) error {
 	}
 }
 
+// FloatSetter sends float request
+func (p *HTTP) FloatSetter(param string) func(float64) error {
+	return func(val float64) error {
+		return p.set(param, val)
+	}
+}
+
 // StringSetter sends string request
 func (p *HTTP) StringSetter(param string) func(string) error {
 	return func(val string) error {
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/evcc-io/evcc/provider/javascript"
@@ -11,7 +12,7 @@ import (
 // Javascript implements Javascript request provider
 type Javascript struct {
 	log    *util.Logger
-	vm     *otto.Otto
+	vm     *javascript.VM
 	script string
 }
 
@@ -23,6 +24,7 @@ func init() {
 func NewJavascriptProviderFromConfig(other map[string]interface{}) (IntProvider, error) {
 	cc := struct {
 		VM     string
+		Init   string // executed once, e.g. for declaring persistent variables
 		Script string
 	}{}
 
@@ -32,7 +34,20 @@ func NewJavascriptProviderFromConfig(other map[string]interface{}) (IntProvider,
 
 	log := util.NewLogger("js")
 
-	vm := javascript.RegisteredVM(strings.ToLower(cc.VM))
+	vm, err := javascript.RegisteredVM(strings.ToLower(cc.VM))
+	if err != nil {
+		return nil, err
+	}
+
+	if cc.Init != "" {
+		vm.Lock()
+		_, err := vm.Run(cc.Init)
+		vm.Unlock()
+
+		if err != nil {
+			return nil, fmt.Errorf("init: %w", err)
+		}
+	}
 
 	p := &Javascript{
 		log:    log,
@@ -43,10 +58,24 @@ func NewJavascriptProviderFromConfig(other map[string]interface{}) (IntProvider,
 	return p, nil
 }
 
+// eval executes the script, setting the param variable before if given
+func (p *Javascript) eval(param string, val interface{}) (otto.Value, error) {
+	p.vm.Lock()
+	defer p.vm.Unlock()
+
+	if param != "" {
+		if err := p.setParam(param, val); err != nil {
+			return otto.Value{}, err
+		}
+	}
+
+	return p.vm.Eval(p.script)
+}
+
 // FloatGetter parses float from request
 func (p *Javascript) FloatGetter() func() (float64, error) {
 	return func() (res float64, err error) {
-		v, err := p.vm.Eval(p.script)
+		v, err := p.eval("", nil)
 		if err == nil {
 			res, err = v.ToFloat()
 		}
@@ -58,7 +87,7 @@ func (p *Javascript) FloatGetter() func() (float64, error) {
 // IntGetter parses int64 from request
 func (p *Javascript) IntGetter() func() (int64, error) {
 	return func() (res int64, err error) {
-		v, err := p.vm.Eval(p.script)
+		v, err := p.eval("", nil)
 		if err == nil {
 			res, err = v.ToInteger()
 		}
@@ -70,7 +99,7 @@ func (p *Javascript) IntGetter() func() (int64, error) {
 // StringGetter sends string request
 func (p *Javascript) StringGetter() func() (string, error) {
 	return func() (res string, err error) {
-		v, err := p.vm.Eval(p.script)
+		v, err := p.eval("", nil)
 		if err == nil {
 			res, err = v.ToString()
 		}
@@ -82,7 +111,7 @@ func (p *Javascript) StringGetter() func() (string, error) {
 // BoolGetter parses bool from request
 func (p *Javascript) BoolGetter() func() (bool, error) {
 	return func() (res bool, err error) {
-		v, err := p.vm.Eval(p.script)
+		v, err := p.eval("", nil)
 		if err == nil {
 			res, err = v.ToBoolean()
 		}
@@ -105,10 +134,15 @@ func (p *Javascript) setParam(param string, val interface{}) error {
 // IntSetter sends int request
 func (p *Javascript) IntSetter(param string) func(int64) error {
 	return func(val int64) error {
-		err := p.setParam(param, val)
-		if err == nil {
-			_, err = p.vm.Eval(p.script)
-		}
+		_, err := p.eval(param, val)
+		return err
+	}
+}
+
+// FloatSetter sends float request
+func (p *Javascript) FloatSetter(param string) func(float64) error {
+	return func(val float64) error {
+		_, err := p.eval(param, val)
 		return err
 	}
 }
@@ -116,10 +150,7 @@ func (p *Javascript) IntSetter(param string) func(int64) error {
 // StringSetter sends string request
 func (p *Javascript) StringSetter(param string) func(string) error {
 	return func(val string) error {
-		err := p.setParam(param, val)
-		if err == nil {
-			_, err = p.vm.Eval(p.script)
-		}
+		_, err := p.eval(param, val)
 		return err
 	}
 }
@@ -127,10 +158,7 @@ func (p *Javascript) StringSetter(param string) func(string) error {
 // BoolSetter sends bool request
 func (p *Javascript) BoolSetter(param string) func(bool) error {
 	return func(val bool) error {
-		err := p.setParam(param, val)
-		if err == nil {
-			_, err = p.vm.Eval(p.script)
-		}
+		_, err := p.eval(param, val)
 		return err
 	}
 }
@@ -0,0 +1,199 @@
+package javascript
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/evcc-io/evcc/provider/mqtt"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/robertkrimen/otto"
+)
+
+var (
+	cacheMu sync.RWMutex
+	cache   *util.Cache
+)
+
+// SetCache makes the cached evcc values available to scripts as read-only evcc.get(key)
+func SetCache(c *util.Cache) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	cache = c
+}
+
+// bind adds the evcc, http and mqtt helper objects to the VM
+func bind(vm *otto.Otto) error {
+	log := util.NewLogger("js")
+
+	for name, fns := range map[string]map[string]func(otto.FunctionCall) otto.Value{
+		"evcc": {
+			"get": evccGet,
+		},
+		"http": {
+			"get":  httpRequest(log, "GET"),
+			"post": httpRequest(log, "POST"),
+		},
+		"mqtt": mqttFunctions(),
+	} {
+		obj, err := vm.Object(`({})`)
+		if err != nil {
+			return err
+		}
+
+		for fn, f := range fns {
+			if err := obj.Set(fn, f); err != nil {
+				return err
+			}
+		}
+
+		if err := vm.Set(name, obj); err != nil {
+			return err
+		}
+
+		if _, err := vm.Run(fmt.Sprintf("Object.freeze(%s)", name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// throw raises a javascript exception from go error
+func throw(call otto.FunctionCall, err error) {
+	panic(call.Otto.MakeCustomError("Error", err.Error()))
+}
+
+// value converts go value to javascript value
+func value(call otto.FunctionCall, val interface{}) otto.Value {
+	v, err := call.Otto.ToValue(val)
+	if err != nil {
+		throw(call, err)
+	}
+	return v
+}
+
+// evccGet returns cached value by key, e.g. gridPower or 0.chargePower for loadpoint values
+func evccGet(call otto.FunctionCall) otto.Value {
+	key := call.Argument(0).String()
+
+	cacheMu.RLock()
+	c := cache
+	cacheMu.RUnlock()
+
+	if c == nil {
+		return otto.UndefinedValue()
+	}
+
+	p := c.Get(key)
+	if p.Key == "" || p.Val == nil {
+		return otto.UndefinedValue()
+	}
+
+	return value(call, p.Val)
+}
+
+// headers converts a javascript object argument to request headers
+func headers(call otto.FunctionCall, arg otto.Value) map[string]string {
+	res := make(map[string]string)
+	if !arg.IsObject() {
+		return res
+	}
+
+	exported, err := arg.Export()
+	if err != nil {
+		throw(call, err)
+	}
+
+	m, ok := exported.(map[string]interface{})
+	if !ok {
+		throw(call, errors.New("invalid headers"))
+	}
+
+	for k, v := range m {
+		res[k] = fmt.Sprintf("%v", v)
+	}
+
+	return res
+}
+
+// httpRequest creates http.get(uri[, headers]) and http.post(uri, body[, headers]) helpers returning the response body
+func httpRequest(log *util.Logger, method string) func(otto.FunctionCall) otto.Value {
+	helper := request.NewHelper(log)
+
+	return func(call otto.FunctionCall) otto.Value {
+		uri := call.Argument(0).String()
+
+		var body io.Reader
+		hdr := call.Argument(1)
+		if method != "GET" {
+			body = strings.NewReader(call.Argument(1).String())
+			hdr = call.Argument(2)
+		}
+
+		req, err := request.New(method, uri, body, headers(call, hdr))
+		if err != nil {
+			throw(call, err)
+		}
+
+		b, err := helper.DoBody(req)
+		if err != nil {
+			throw(call, err)
+		}
+
+		return value(call, string(b))
+	}
+}
+
+// mqttFunctions creates mqtt.publish(topic, payload) and mqtt.get(topic) helpers.
+// The topic is subscribed on first mqtt.get, returning undefined until a message has been received.
+func mqttFunctions() map[string]func(otto.FunctionCall) otto.Value {
+	var mu sync.Mutex
+	received := make(map[string]*string)
+
+	client := func(call otto.FunctionCall) *mqtt.Client {
+		if mqtt.Instance == nil {
+			throw(call, errors.New("mqtt not configured"))
+		}
+		return mqtt.Instance
+	}
+
+	return map[string]func(otto.FunctionCall) otto.Value{
+		"publish": func(call otto.FunctionCall) otto.Value {
+			topic, payload := call.Argument(0).String(), call.Argument(1).String()
+			if err := client(call).Publish(topic, false, payload); err != nil {
+				throw(call, err)
+			}
+			return otto.UndefinedValue()
+		},
+		"get": func(call otto.FunctionCall) otto.Value {
+			topic := call.Argument(0).String()
+			c := client(call)
+
+			mu.Lock()
+			val, ok := received[topic]
+			if !ok {
+				received[topic] = nil
+			}
+			mu.Unlock()
+
+			if !ok {
+				c.Listen(topic, func(payload string) {
+					mu.Lock()
+					received[topic] = &payload
+					mu.Unlock()
+				})
+			}
+
+			if val == nil {
+				return otto.UndefinedValue()
+			}
+
+			return value(call, *val)
+		},
+	}
+}
@@ -1,11 +1,29 @@
 package javascript
 
 import (
+	"sync"
+
 	"github.com/evcc-io/evcc/util"
 	"github.com/robertkrimen/otto"
 	_ "github.com/robertkrimen/otto/underscore"
 )
 
+// VM is a JS VM guarded against concurrent access
+type VM struct {
+	sync.Mutex
+	*otto.Otto
+}
+
+// newVM creates a JS VM with the evcc, http and mqtt helpers
+func newVM() (*VM, error) {
+	vm := otto.New()
+	if err := bind(vm); err != nil {
+		return nil, err
+	}
+
+	return &VM{Otto: vm}, nil
+}
+
 // Configure initializes JS VMs
 func Configure(other map[string]interface{}) error {
 	cc := []struct {
@@ -17,6 +35,9 @@ func Configure(other map[string]interface{}) error {
 		return err
 	}
 
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
 	// init all VMs that require it
 	for _, conf := range cc {
 		if conf.Script == "" {
@@ -24,13 +45,15 @@ func Configure(other map[string]interface{}) error {
 		}
 
 		if _, ok := registry[conf.VM]; !ok {
-			vm := otto.New()
-
-			_, err := vm.Run(conf.Script)
+			vm, err := newVM()
 			if err != nil {
 				return err
 			}
 
+			if _, err := vm.Run(conf.Script); err != nil {
+				return err
+			}
+
 			registry[conf.VM] = vm
 		}
 	}
@@ -38,20 +61,30 @@ func Configure(other map[string]interface{}) error {
 	return nil
 }
 
-var registry = make(map[string]*otto.Otto)
+var (
+	registryMu sync.Mutex
+	registry   = make(map[string]*VM)
+)
 
 // RegisteredVM returns a JS VM. If name is not empty, it will return a shared instance.
-func RegisteredVM(name string) *otto.Otto {
+// Otherwise an isolated VM is created.
+func RegisteredVM(name string) (*VM, error) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
 	vm, ok := registry[name]
 
 	// create new VM
 	if !ok {
-		vm = otto.New()
+		var err error
+		if vm, err = newVM(); err != nil {
+			return nil, err
+		}
 
 		if name != "" {
 			registry[name] = vm
 		}
 	}
 
-	return vm
+	return vm, nil
 }
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/evcc-io/evcc/provider/javascript"
+	"github.com/evcc-io/evcc/util"
+)
+
+func TestJavascriptState(t *testing.T) {
+	p, err := NewJavascriptProviderFromConfig(map[string]interface{}{
+		"init":   "var count = 0;",
+		"script": "++count;",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := p.IntGetter()
+	for i := int64(1); i <= 3; i++ {
+		if res, err := g(); res != i || err != nil {
+			t.Errorf("expected %d, got %d %v", i, res, err)
+		}
+	}
+
+	// isolated vm
+	p2, err := NewJavascriptProviderFromConfig(map[string]interface{}{
+		"script": "typeof count",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res, err := p2.(StringProvider).StringGetter()(); res != "undefined" || err != nil {
+		t.Errorf("unexpected shared state: %s %v", res, err)
+	}
+}
+
+func TestJavascriptBindings(t *testing.T) {
+	cache := util.NewCache()
+	cache.Add("gridPower", util.Param{Key: "gridPower", Val: -1500.0})
+	javascript.SetCache(cache)
+	defer javascript.SetCache(nil)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"power": %s}`, r.Header.Get("X-Power"))
+	}))
+	defer srv.Close()
+
+	p, err := NewJavascriptProviderFromConfig(map[string]interface{}{
+		"script": fmt.Sprintf(`
+			var res = JSON.parse(http.get("%s", {"X-Power": 500}));
+			res.power - evcc.get("gridPower");
+		`, srv.URL),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res, err := p.(FloatProvider).FloatGetter()(); res != 2000 || err != nil {
+		t.Errorf("expected 2000, got %.0f %v", res, err)
+	}
+
+	// read-only
+	p, err = NewJavascriptProviderFromConfig(map[string]interface{}{
+		"script": `evcc.get = null; typeof evcc.get`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res, err := p.(StringProvider).StringGetter()(); res != "function" || err != nil {
+		t.Errorf("unexpected evcc binding: %s %v", res, err)
+	}
+
+	// errors are thrown
+	p, err = NewJavascriptProviderFromConfig(map[string]interface{}{
+		"script": `try { mqtt.publish("foo", "bar"); "ok" } catch (e) { e.message }`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res, err := p.(StringProvider).StringGetter()(); res != "mqtt not configured" || err != nil {
+		t.Errorf("unexpected result: %s %v", res, err)
+	}
+}
+
+func TestJavascriptConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			javascript.SetCache(util.NewCache())
+			if _, err := javascript.RegisteredVM("concurrent"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+	javascript.SetCache(nil)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 
 	"github.com/evcc-io/evcc/util"
@@ -20,6 +21,7 @@ type Modbus struct {
 	conn   *modbus.Connection
 	device meters.Device
 	op     modbus.Operation
+	encode string
 	scale  float64
 }
 
@@ -32,9 +34,12 @@ func NewModbusFromConfig(other map[string]interface{}) (IntProvider, error) {
 	cc := struct {
 		Model           string
 		modbus.Settings `mapstructure:",squash"`
-		Register        modbus.Register
-		Value           string
-		Scale           float64
+		Register        struct {
+			modbus.Register `mapstructure:",squash"`
+			Encode          string // write encoding, defaults to uint16
+		}
+		Value string
+		Scale float64
 	}{
 		Scale: 1,
 	}
@@ -65,11 +70,26 @@ func NewModbusFromConfig(other map[string]interface{}) (IntProvider, error) {
 	var device meters.Device
 	var op modbus.Operation
 
-	if cc.Value != "" && cc.Register.Decode != "" {
+	// coils don't require decoding
+	register := cc.Register.Decode != "" || cc.Register.Type != ""
+
+	// registers written without decoding, values are written as uint16 unless encoded otherwise
+	if register && cc.Register.Decode == "" {
+		switch strings.ToLower(cc.Register.Type) {
+		case "holding", "writesingle", "writemultiple":
+			cc.Register.Decode = "uint16"
+		}
+	}
+
+	if cc.Register.Encode == "" {
+		cc.Register.Encode = "uint16"
+	}
+
+	if cc.Value != "" && register {
 		return nil, errors.New("modbus cannot have value and register both")
 	}
 
-	if cc.Value == "" && cc.Register.Decode == "" {
+	if cc.Value == "" && !register {
 		log.WARN.Println("missing modbus value or register - assuming Power")
 		cc.Value = "Power"
 	}
@@ -79,7 +99,7 @@ func NewModbusFromConfig(other map[string]interface{}) (IntProvider, error) {
 	}
 
 	// no registered configured - need device
-	if cc.Register.Decode == "" {
+	if !register {
 		device, err = modbus.NewDevice(cc.Model, cc.SubDevice)
 
 		// prepare device
@@ -106,8 +126,8 @@ func NewModbusFromConfig(other map[string]interface{}) (IntProvider, error) {
 	}
 
 	// register configured
-	if cc.Register.Decode != "" {
-		if op.MBMD, err = modbus.RegisterOperation(cc.Register); err != nil {
+	if register {
+		if op.MBMD, err = modbus.RegisterOperation(cc.Register.Register); err != nil {
 			return nil, err
 		}
 	}
@@ -117,6 +137,7 @@ func NewModbusFromConfig(other map[string]interface{}) (IntProvider, error) {
 		conn:   conn,
 		device: device,
 		op:     op,
+		encode: cc.Register.Encode,
 		scale:  cc.Scale,
 	}
 	return mb, nil
@@ -129,6 +150,8 @@ func (m *Modbus) bytesGetter() ([]byte, error) {
 			return m.conn.ReadHoldingRegisters(op.OpCode, op.ReadLen)
 		case rs485.ReadInputReg:
 			return m.conn.ReadInputRegisters(op.OpCode, op.ReadLen)
+		case modbus.ReadCoils:
+			return m.conn.ReadCoils(op.OpCode, op.ReadLen)
 		default:
 			return nil, fmt.Errorf("unknown function code %d", op.FuncCode)
 		}
@@ -239,29 +262,73 @@ func (m *Modbus) BoolGetter() func() (bool, error) {
 	}
 }
 
-// IntSetter executes configured modbus write operation and implements SetIntProvider
-func (m *Modbus) IntSetter(param string) func(int64) error {
-	return func(val int64) error {
+// FloatSetter executes configured modbus write operation and implements SetFloatProvider
+func (m *Modbus) FloatSetter(param string) func(float64) error {
+	return func(val float64) error {
+		op := m.op.MBMD
+		if op.FuncCode == 0 {
+			return errors.New("modbus plugin does not support writing to sunspec")
+		}
+
+		val = m.scale * val
+
 		var err error
+		switch op.FuncCode {
+		case modbus.ReadCoils, modbus.WriteSingleCoil:
+			var u uint16
+			if val != 0 {
+				u = 0xFF00
+			}
+			_, err = m.conn.WriteSingleCoil(op.OpCode, u)
 
-		// if funccode is configured, execute the read directly
-		if op := m.op.MBMD; op.FuncCode != 0 {
-			uval := uint16(int64(m.scale) * val)
+		case rs485.ReadHoldingReg, modbus.WriteSingleRegister, modbus.WriteMultipleRegisters:
+			var b []byte
+			if b, err = modbus.EncodeRegister(m.encode, val); err != nil {
+				return err
+			}
 
-			switch op.FuncCode {
-			case modbus.WriteSingleRegister:
-				_, err = m.conn.WriteSingleRegister(op.OpCode, uval)
-			default:
-				err = fmt.Errorf("unknown function code %d", op.FuncCode)
+			if op.FuncCode == modbus.WriteSingleRegister && len(b) > 2 {
+				return fmt.Errorf("invalid register encoding for single register write: %s", m.encode)
 			}
-		} else {
-			err = errors.New("modbus plugin does not support writing to sunspec")
+
+			// holding registers are written as single register if possible
+			if op.FuncCode == modbus.WriteMultipleRegisters || len(b) > 2 {
+				_, err = m.conn.WriteMultipleRegisters(op.OpCode, uint16(len(b)/2), b)
+			} else {
+				_, err = m.conn.WriteSingleRegister(op.OpCode, binary.BigEndian.Uint16(b))
+			}
+
+		default:
+			err = fmt.Errorf("unknown function code %d", op.FuncCode)
 		}
 
 		return err
 	}
 }
 
+// IntSetter executes configured modbus write operation and implements SetIntProvider
+func (m *Modbus) IntSetter(param string) func(int64) error {
+	set := m.FloatSetter(param)
+
+	return func(val int64) error {
+		return set(float64(val))
+	}
+}
+
+// StringSetter executes configured modbus write operation and implements SetStringProvider
+func (m *Modbus) StringSetter(param string) func(string) error {
+	set := m.FloatSetter(param)
+
+	return func(val string) error {
+		f, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if err != nil {
+			return err
+		}
+
+		return set(f)
+	}
+}
+
 // BoolSetter executes configured modbus write operation and implements SetBoolProvider
 func (m *Modbus) BoolSetter(param string) func(bool) error {
 	set := m.IntSetter(param)
@@ -0,0 +1,24 @@
+package provider
+
+import "testing"
+
+func TestModbusWriteRegister(t *testing.T) {
+	for _, reg := range []map[string]interface{}{
+		{"address": 100, "type": "writesingle", "decode": "int32"},
+		{"address": 100, "type": "holding"},
+		{"address": 100, "type": "writemultiple", "encode": "float32"},
+	} {
+		p, err := NewModbusFromConfig(map[string]interface{}{
+			"uri":      "192.0.2.2:502",
+			"register": reg,
+		})
+		if err != nil {
+			t.Errorf("%v: %v", reg, err)
+			continue
+		}
+
+		if m := p.(*Modbus); reg["encode"] == nil && m.encode != "uint16" {
+			t.Errorf("%v: expected uint16 encoding, got %s", reg, m.encode)
+		}
+	}
+}
@@ -205,19 +205,33 @@ func (m *Mqtt) BoolSetter(param string) func(bool) error {
 	}
 }
 
-// var _ SetStringProvider = (*Mqtt)(nil)
-
-// // StringSetter invokes script with parameter replaced by string value
-// func (m *Mqtt) StringSetter(param string) func(string) error {
-// 	return func(v string) error {
-// 		payload, err := setFormattedValue(m.payload, param, v)
-// 		if err != nil {
-// 			return err
-// 		}
-
-// 		return m.client.Publish(m.topic, false, payload)
-// 	}
-// }
+var _ SetFloatProvider = (*Mqtt)(nil)
+
+// FloatSetter publishes topic with parameter replaced by float value
+func (m *Mqtt) FloatSetter(param string) func(float64) error {
+	return func(v float64) error {
+		payload, err := setFormattedValue(m.payload, param, v)
+		if err != nil {
+			return err
+		}
+
+		return m.client.Publish(m.topic, false, payload)
+	}
+}
+
+var _ SetStringProvider = (*Mqtt)(nil)
+
+// StringSetter publishes topic with parameter replaced by string value
+func (m *Mqtt) StringSetter(param string) func(string) error {
+	return func(v string) error {
+		payload, err := setFormattedValue(m.payload, param, v)
+		if err != nil {
+			return err
+		}
+
+		return m.client.Publish(m.topic, false, payload)
+	}
+}
 
 type msgHandler struct {
 	mux     *util.Waiter
@@ -212,3 +212,33 @@ func (e *Script) BoolSetter(param string) func(bool) error {
 		return err
 	}
 }
+
+// FloatSetter invokes script with parameter replaced by float value
+func (e *Script) FloatSetter(param string) func(float64) error {
+	return func(f float64) error {
+		cmd, err := util.ReplaceFormatted(e.script, map[string]interface{}{
+			param: f,
+		})
+
+		if err == nil {
+			_, err = e.exec(cmd)
+		}
+
+		return err
+	}
+}
+
+// StringSetter invokes script with parameter replaced by string value
+func (e *Script) StringSetter(param string) func(string) error {
+	return func(s string) error {
+		cmd, err := util.ReplaceFormatted(e.script, map[string]interface{}{
+			param: s,
+		})
+
+		if err == nil {
+			_, err = e.exec(cmd)
+		}
+
+		return err
+	}
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"html/template"
@@ -12,7 +13,9 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/templates"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/test"
 	"github.com/gorilla/handlers"
@@ -42,6 +45,10 @@ type phasesJSON struct {
 	Phases int `json:"phases"`
 }
 
+type departureJSON struct {
+	Departure string `json:"departureTime"`
+}
+
 type route struct {
 	Methods     []string
 	Pattern     string
@@ -115,7 +122,7 @@ func HealthHandler(site site.API) http.HandlerFunc {
 	}
 }
 
-// TemplatesHandler returns current charge mode
+// TemplatesHandler returns device templates and configuration samples for the given class
 func TemplatesHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -126,11 +133,26 @@ func TemplatesHandler() http.HandlerFunc {
 		}
 
 		type template = struct {
-			Name   string `json:"name"`
-			Sample string `json:"template"`
+			Name         string            `json:"name"`
+			Sample       string            `json:"template"`
+			Capabilities []string          `json:"capabilities,omitempty"`
+			Params       []templates.Param `json:"params,omitempty"`
 		}
 
 		res := make([]template, 0)
+
+		// parameterized device templates
+		for _, tmpl := range templates.ByClass(class) {
+			t := template{
+				Name:         tmpl.Description,
+				Sample:       tmpl.Usage(nil),
+				Capabilities: tmpl.Capabilities,
+				Params:       tmpl.Params,
+			}
+			res = append(res, t)
+		}
+
+		// static configuration samples
 		for _, conf := range test.ConfigTemplates(class) {
 			typedSample := fmt.Sprintf("type: %s\n%s", conf.Type, conf.Sample)
 			t := template{
@@ -155,6 +177,46 @@ func StateHandler(cache *util.Cache) http.HandlerFunc {
 	}
 }
 
+// SessionsHandler returns completed charging sessions as JSON or CSV, optionally filtered by vehicle
+func SessionsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res := session.All(r.URL.Query().Get("vehicle"))
+
+		if r.URL.Query().Get("format") != "csv" {
+			jsonResponse(w, r, res)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/csv; charset=UTF-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="sessions.csv"`)
+		w.WriteHeader(http.StatusOK)
+
+		cw := csv.NewWriter(w)
+		_ = cw.Write([]string{
+			"Loadpoint", "Vehicle", "Connected", "Disconnected", "Charged Energy (kWh)", "Discharged Energy (kWh)",
+			"SoC Start (%)", "SoC End (%)", "Odometer Start (km)", "Odometer End (km)",
+			"Distance (km)", "Consumption (kWh/100km)",
+		})
+
+		f := func(f float64, prec int) string {
+			return strconv.FormatFloat(f, 'f', prec, 64)
+		}
+
+		for _, s := range res {
+			_ = cw.Write([]string{
+				s.Loadpoint, s.Vehicle, s.Connected.Format(time.RFC3339), s.Disconnected.Format(time.RFC3339), f(s.ChargedEnergy, 3), f(s.DischargedEnergy, 3),
+				f(s.SoCStart, 0), f(s.SoCEnd, 0), f(s.OdometerStart, 0), f(s.OdometerEnd, 0),
+				f(s.Distance, 0), f(s.Consumption, 1),
+			})
+		}
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			log.ERROR.Printf("httpd: failed to encode CSV: %v", err)
+		}
+	}
+}
+
 // CurrentChargeModeHandler returns current charge mode
 func CurrentChargeModeHandler(lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -277,6 +339,35 @@ func PhasesHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// CurrentDepartureHandler returns current departure time
+func CurrentDepartureHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res := departureJSON{Departure: lp.GetDepartureTime()}
+		jsonResponse(w, r, res)
+	}
+}
+
+// DepartureHandler updates departure time, removing it for DELETE requests
+func DepartureHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		var departure string
+		if r.Method != http.MethodDelete {
+			departure = vars["time"]
+		}
+
+		if err := lp.SetDepartureTime(departure); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			jsonResponse(w, r, errorJSON{Error: err.Error()})
+			return
+		}
+
+		res := departureJSON{Departure: lp.GetDepartureTime()}
+		jsonResponse(w, r, res)
+	}
+}
+
 // RemoteDemandHandler updates minimum soc
 func RemoteDemandHandler(lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -375,6 +466,7 @@ func NewHTTPd(url string, site site.API, hub *SocketHub, cache *util.Cache) *HTT
 		"health":    {[]string{"GET"}, "/health", HealthHandler(site)},
 		"state":     {[]string{"GET"}, "/state", StateHandler(cache)},
 		"templates": {[]string{"GET"}, "/config/templates/{class:[a-z]+}", TemplatesHandler()},
+		"sessions":  {[]string{"GET"}, "/sessions", SessionsHandler()},
 	}
 
 	router := mux.NewRouter().StrictSlash(true)
@@ -421,6 +513,9 @@ func NewHTTPd(url string, site site.API, hub *SocketHub, cache *util.Cache) *HTT
 			"setphases":       {[]string{"POST", "OPTIONS"}, "/phases/{phases:[0-9]+}", PhasesHandler(lp)},
 			"settargetcharge": {[]string{"POST", "OPTIONS"}, "/targetcharge/{soc:[0-9]+}/{time:[0-9TZ:-]+}", TargetChargeHandler(lp)},
 			"remotedemand":    {[]string{"POST", "OPTIONS"}, "/remotedemand/{demand:[a-z]+}/{source::[0-9a-zA-Z_-]+}", RemoteDemandHandler(lp)},
+			"getdeparture":    {[]string{"GET"}, "/departure", CurrentDepartureHandler(lp)},
+			"setdeparture":    {[]string{"POST", "OPTIONS"}, "/departure/{time:[0-9]{2}:[0-9]{2}}", DepartureHandler(lp)},
+			"deletedeparture": {[]string{"DELETE", "OPTIONS"}, "/departure", DepartureHandler(lp)},
 		}
 
 		for _, r := range routes {
@@ -87,6 +87,9 @@ func (m *MQTT) listenSetters(topic string, apiHandler loadpoint.API) {
 			_ = apiHandler.SetPhases(phases)
 		}
 	})
+	m.Handler.Listen(topic+"/departureTime/set", func(payload string) {
+		_ = apiHandler.SetDepartureTime(payload)
+	})
 }
 
 // Run starts the MQTT publisher for the MQTT API
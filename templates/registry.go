@@ -0,0 +1,62 @@
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed definition
+var definitions embed.FS
+
+// registry contains all templates by class and name
+var registry = make(map[string]map[string]Template)
+
+func init() {
+	for _, class := range []string{Charger, Meter, Vehicle} {
+		registry[class] = make(map[string]Template)
+
+		files, err := fs.ReadDir(definitions, path.Join("definition", class))
+		if err != nil {
+			panic(err)
+		}
+
+		for _, file := range files {
+			if err := load(class, path.Join("definition", class, file.Name())); err != nil {
+				panic(fmt.Sprintf("%s: %v", file.Name(), err))
+			}
+		}
+	}
+}
+
+// load parses and validates a template definition
+func load(class, file string) error {
+	b, err := fs.ReadFile(definitions, file)
+	if err != nil {
+		return err
+	}
+
+	var t Template
+	if err := yaml.Unmarshal(b, &t); err != nil {
+		return err
+	}
+
+	t.Class = class
+	t.Template = strings.ToLower(t.Template)
+
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	if _, exists := registry[class][t.Template]; exists {
+		return fmt.Errorf("duplicate %s template: %s", class, t.Template)
+	}
+
+	registry[class][t.Template] = t
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Device classes
+const (
+	Charger = "charger"
+	Meter   = "meter"
+	Vehicle = "vehicle"
+)
+
+// Param types
+const (
+	TypeString   = "string"
+	TypeInt      = "int"
+	TypeFloat    = "float"
+	TypeBool     = "bool"
+	TypeDuration = "duration"
+)
+
+// funcs are the functions available for rendering
+var funcs = template.FuncMap{
+	"quote": quote,
+}
+
+// quote concatenates the values and renders them as YAML-safe double-quoted string.
+// All user-provided values must be quoted to prevent breaking or extending the rendered configuration.
+func quote(vals ...interface{}) (string, error) {
+	var s strings.Builder
+	for _, v := range vals {
+		fmt.Fprintf(&s, "%v", v)
+	}
+
+	// JSON strings are valid YAML double-quoted scalars
+	b, err := json.Marshal(s.String())
+	return string(b), err
+}
+
+// Param is a typed template parameter
+type Param struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Template describes a device once and renders its configuration from parameters
+type Template struct {
+	Template     string   `json:"template"`
+	Class        string   `json:"class"`
+	Description  string   `json:"description"`
+	Capabilities []string `json:"capabilities,omitempty"`
+	Detect       []string `json:"-"` // detect task ids identifying the device
+	Params       []Param  `json:"params"`
+	Render       string   `json:"-"`
+}
+
+// Param returns the named parameter
+func (t *Template) Param(name string) (Param, bool) {
+	for _, p := range t.Params {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+	return Param{}, false
+}
+
+// Validate checks the template definition
+func (t *Template) Validate() error {
+	if t.Template == "" {
+		return errors.New("missing template name")
+	}
+
+	if t.Render == "" {
+		return errors.New("missing render")
+	}
+
+	for _, p := range t.Params {
+		if p.Name == "" {
+			return errors.New("missing param name")
+		}
+
+		if _, err := p.convert(p.Default); p.Default != "" && err != nil {
+			return fmt.Errorf("param %s: invalid default: %w", p.Name, err)
+		}
+	}
+
+	_, err := template.New(t.Template).Funcs(funcs).Parse(t.Render)
+
+	return err
+}
+
+// convert converts the parameter value to the parameter's type
+func (p Param) convert(val interface{}) (res interface{}, err error) {
+	s := fmt.Sprintf("%v", val)
+
+	switch strings.ToLower(p.Type) {
+	case "", TypeString:
+		res = s
+	case TypeInt:
+		res, err = strconv.ParseInt(s, 10, 64)
+	case TypeFloat:
+		res, err = strconv.ParseFloat(s, 64)
+	case TypeBool:
+		res, err = strconv.ParseBool(s)
+	case TypeDuration:
+		_, err = time.ParseDuration(s)
+		res = s
+	default:
+		err = fmt.Errorf("invalid type: %s", p.Type)
+	}
+
+	return res, err
+}
+
+// values validates the given values against the template's params and applies defaults
+func (t *Template) values(other map[string]interface{}) (map[string]interface{}, error) {
+	res := make(map[string]interface{})
+
+	for k, v := range other {
+		p, ok := t.Param(k)
+		if !ok {
+			return nil, fmt.Errorf("invalid param: %s", k)
+		}
+
+		val, err := p.convert(v)
+		if err != nil {
+			return nil, fmt.Errorf("param %s: %w", p.Name, err)
+		}
+
+		res[p.Name] = val
+	}
+
+	for _, p := range t.Params {
+		if _, ok := res[p.Name]; ok {
+			continue
+		}
+
+		if p.Required {
+			return nil, fmt.Errorf("missing required param: %s", p.Name)
+		}
+
+		if p.Default != "" {
+			res[p.Name], _ = p.convert(p.Default)
+		}
+	}
+
+	return res, nil
+}
+
+// RenderConfig validates the given values and renders the device configuration
+func (t *Template) RenderConfig(other map[string]interface{}) (string, map[string]interface{}, error) {
+	values, err := t.values(other)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tmpl, err := template.New(t.Template).Funcs(funcs).Option("missingkey=zero").Parse(t.Render)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, values); err != nil {
+		return "", nil, err
+	}
+
+	var conf map[string]interface{}
+	if err := yaml.Unmarshal(b.Bytes(), &conf); err != nil {
+		return "", nil, fmt.Errorf("rendering: %w", err)
+	}
+
+	typ, ok := conf["type"].(string)
+	if !ok || typ == "" {
+		return "", nil, errors.New("rendering: missing type")
+	}
+	delete(conf, "type")
+
+	return typ, conf, nil
+}
+
+// Usage returns a configuration snippet for referencing the template.
+// Parameters without given value are added with their default or as empty placeholders.
+func (t *Template) Usage(values map[string]interface{}) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "type: template\ntemplate: %s\n", t.Template)
+
+	for _, p := range t.Params {
+		if v, ok := values[p.Name]; ok {
+			fmt.Fprintf(&b, "%s: %v\n", p.Name, v)
+			continue
+		}
+
+		if !p.Required && p.Default == "" {
+			continue
+		}
+
+		comment := p.Description
+		if p.Required {
+			comment = strings.TrimSpace("required " + comment)
+		}
+
+		if comment != "" {
+			comment = " # " + comment
+		}
+
+		fmt.Fprintf(&b, "%s: %s%s\n", p.Name, p.Default, comment)
+	}
+
+	return b.String()
+}
+
+// ByClass returns the templates for the given device class sorted by name
+func ByClass(class string) []Template {
+	var res []Template
+	for _, t := range registry[class] {
+		res = append(res, t)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Template < res[j].Template
+	})
+
+	return res
+}
+
+// ByName returns the named template of the given device class
+func ByName(class, name string) (Template, error) {
+	t, ok := registry[class][strings.ToLower(name)]
+	if !ok {
+		return t, fmt.Errorf("%s template not found: %s", class, name)
+	}
+	return t, nil
+}
+
+// ByDetect returns all templates that are identified by the given detect task id
+func ByDetect(id string) []Template {
+	var res []Template
+	for _, class := range []string{Charger, Meter, Vehicle} {
+		for _, t := range ByClass(class) {
+			for _, d := range t.Detect {
+				if d == id {
+					res = append(res, t)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// Instantiate renders the device configuration for a `type: template` configuration
+func Instantiate(class string, other map[string]interface{}) (string, map[string]interface{}, error) {
+	values := make(map[string]interface{})
+
+	var name string
+	for k, v := range other {
+		if strings.EqualFold(k, "template") {
+			name = fmt.Sprintf("%v", v)
+			continue
+		}
+		values[k] = v
+	}
+
+	if name == "" {
+		return "", nil, errors.New("missing template")
+	}
+
+	t, err := ByName(class, name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	typ, conf, err := t.RenderConfig(values)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", t.Template, err)
+	}
+
+	return typ, conf, err
+}
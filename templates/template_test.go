@@ -0,0 +1,150 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefinitions(t *testing.T) {
+	for _, class := range []string{Charger, Meter, Vehicle} {
+		if len(ByClass(class)) == 0 {
+			t.Errorf("no %s templates", class)
+		}
+	}
+}
+
+func TestInstantiate(t *testing.T) {
+	typ, conf, err := Instantiate(Meter, map[string]interface{}{
+		"template": "sma-home-manager",
+		"host":     "192.0.2.2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if typ != "sma" {
+		t.Errorf("unexpected type: %s", typ)
+	}
+
+	if conf["uri"] != "192.0.2.2" {
+		t.Errorf("unexpected uri: %v", conf["uri"])
+	}
+
+	// default value
+	if conf["password"] != "0000" {
+		t.Errorf("unexpected password: %v", conf["password"])
+	}
+}
+
+func TestInstantiateTyped(t *testing.T) {
+	_, conf, err := Instantiate(Charger, map[string]interface{}{
+		"template": "wallbe",
+		"host":     "192.0.2.2",
+		"port":     "5020",
+		"meter":    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if conf["uri"] != "192.0.2.2:5020" {
+		t.Errorf("unexpected uri: %v", conf["uri"])
+	}
+
+	if _, ok := conf["meter"].(map[string]interface{}); !ok {
+		t.Errorf("missing meter: %v", conf)
+	}
+
+	if _, _, err := Instantiate(Charger, map[string]interface{}{
+		"template": "wallbe",
+		"host":     "192.0.2.2",
+		"port":     "foo",
+	}); err == nil {
+		t.Error("expected invalid int error")
+	}
+}
+
+func TestInstantiateErrors(t *testing.T) {
+	tc := []struct {
+		other map[string]interface{}
+		err   string
+	}{
+		{map[string]interface{}{"host": "192.0.2.2"}, "missing template"},
+		{map[string]interface{}{"template": "foo"}, "template not found"},
+		{map[string]interface{}{"template": "sma-home-manager"}, "missing required param: host"},
+		{map[string]interface{}{"template": "sma-home-manager", "host": "192.0.2.2", "foo": "bar"}, "invalid param: foo"},
+	}
+
+	for _, tc := range tc {
+		_, _, err := Instantiate(Meter, tc.other)
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%v: expected %q, got %v", tc.other, tc.err, err)
+		}
+	}
+}
+
+func TestUsage(t *testing.T) {
+	tmpl, err := ByName(Meter, "sunspec-inverter")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usage := tmpl.Usage(map[string]interface{}{"host": "192.0.2.2"})
+	for _, s := range []string{"type: template\n", "template: sunspec-inverter\n", "host: 192.0.2.2\n", "port: 502\n"} {
+		if !strings.Contains(usage, s) {
+			t.Errorf("usage missing %q:\n%s", s, usage)
+		}
+	}
+}
+
+func TestInstantiateQuoted(t *testing.T) {
+	password := "a\"b\\c #d: e\ninjected: true"
+
+	_, conf, err := Instantiate(Vehicle, map[string]interface{}{
+		"template": "vw",
+		"user":     "foo: bar",
+		"password": password,
+		"capacity": 50,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if conf["user"] != "foo: bar" || conf["password"] != password {
+		t.Errorf("unexpected values: %v", conf)
+	}
+
+	if _, ok := conf["injected"]; ok {
+		t.Errorf("unexpected key: %v", conf)
+	}
+}
+
+// TestDefinitionsQuoted verifies that all templates quote string values
+func TestDefinitionsQuoted(t *testing.T) {
+	for _, class := range []string{Charger, Meter, Vehicle} {
+		for _, tmpl := range ByClass(class) {
+			values := make(map[string]interface{})
+			for _, p := range tmpl.Params {
+				switch p.Type {
+				case "", TypeString:
+					values[p.Name] = "x\" #\ninjected: true"
+				default:
+					values[p.Name] = p.Default
+					if p.Default == "" {
+						values[p.Name], _ = p.convert(map[string]string{TypeInt: "1", TypeFloat: "1", TypeBool: "true", TypeDuration: "1s"}[p.Type])
+					}
+				}
+			}
+
+			_, conf, err := tmpl.RenderConfig(values)
+			if err != nil {
+				t.Errorf("%s: %v", tmpl.Template, err)
+				continue
+			}
+
+			if _, ok := conf["injected"]; ok {
+				t.Errorf("%s: unquoted value", tmpl.Template)
+			}
+		}
+	}
+}
@@ -1,11 +1,44 @@
 package util
 
 import (
+	"errors"
+
 	"github.com/mitchellh/mapstructure"
 )
 
+// ErrDecodeOnly is returned by DecodeOther for decode-only configurations after successful decoding
+var ErrDecodeOnly = errors.New("decode only")
+
+// decodeOnlyKey marks decode-only configurations
+const decodeOnlyKey = "__decodeonly"
+
+// DecodeOnly returns a copy of the configuration marked for decode-only mode. DecodeOther returns
+// ErrDecodeOnly instead of nil for marked configurations, so device constructors stop before
+// connecting to the device. Other configurations are not affected.
+func DecodeOnly(other map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(other)+1)
+	for k, v := range other {
+		res[k] = v
+	}
+	res[decodeOnlyKey] = true
+	return res
+}
+
 // DecodeOther uses mapstructure to decode into target structure. Unused keys cause errors.
 func DecodeOther(other interface{}, cc interface{}) error {
+	var decodeOnly bool
+	if m, ok := other.(map[string]interface{}); ok {
+		if _, decodeOnly = m[decodeOnlyKey]; decodeOnly {
+			res := make(map[string]interface{}, len(m))
+			for k, v := range m {
+				if k != decodeOnlyKey {
+					res[k] = v
+				}
+			}
+			other = res
+		}
+	}
+
 	decoderConfig := &mapstructure.DecoderConfig{
 		Result:           cc,
 		ErrorUnused:      true,
@@ -21,5 +54,9 @@ func DecodeOther(other interface{}, cc interface{}) error {
 		err = decoder.Decode(other)
 	}
 
+	if err == nil && decodeOnly {
+		err = ErrDecodeOnly
+	}
+
 	return err
 }
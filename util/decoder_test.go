@@ -0,0 +1,26 @@
+package util
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodeOnly(t *testing.T) {
+	other := map[string]interface{}{"foo": "bar"}
+
+	var cc struct{ Foo string }
+	if err := DecodeOther(DecodeOnly(other), &cc); !errors.Is(err, ErrDecodeOnly) || cc.Foo != "bar" {
+		t.Errorf("expected decode only, got %v (%+v)", err, cc)
+	}
+
+	// original configuration is not marked
+	if err := DecodeOther(other, &cc); err != nil {
+		t.Error(err)
+	}
+
+	// unknown keys are still reported
+	other["baz"] = true
+	if err := DecodeOther(DecodeOnly(other), &cc); err == nil || errors.Is(err, ErrDecodeOnly) {
+		t.Errorf("expected decoding error, got %v", err)
+	}
+}
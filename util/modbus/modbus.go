@@ -1,8 +1,10 @@
 package modbus
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -13,8 +15,16 @@ import (
 	"github.com/volkszaehler/mbmd/meters/sunspec"
 )
 
-// WriteSingleRegister 16-bit wise write access
-const WriteSingleRegister = 6 // modbus.FuncCodeWriteSingleRegister
+const (
+	// ReadCoils bit wise read access
+	ReadCoils = 1 // modbus.FuncCodeReadCoils
+	// WriteSingleCoil bit wise write access
+	WriteSingleCoil = 5 // modbus.FuncCodeWriteSingleCoil
+	// WriteSingleRegister 16-bit wise write access
+	WriteSingleRegister = 6 // modbus.FuncCodeWriteSingleRegister
+	// WriteMultipleRegisters 16-bit wise write access to consecutive registers
+	WriteMultipleRegisters = 16 // modbus.FuncCodeWriteMultipleRegisters
+)
 
 type WireFormat int
 
@@ -245,7 +255,7 @@ type Register struct {
 	Decode  string
 }
 
-// RegisterOperation creates a read operation from a register definition
+// RegisterOperation creates a read or write operation from a register definition
 func RegisterOperation(r Register) (rs485.Operation, error) {
 	op := rs485.Operation{
 		OpCode:  r.Address,
@@ -257,8 +267,19 @@ func RegisterOperation(r Register) (rs485.Operation, error) {
 		op.FuncCode = rs485.ReadHoldingReg
 	case "input":
 		op.FuncCode = rs485.ReadInputReg
+	case "coil":
+		op.FuncCode = ReadCoils
+		op.ReadLen = 1
+		op.Transform = coilToFloat64
+		return op, nil
+	case "writecoil":
+		op.FuncCode = WriteSingleCoil
+		op.ReadLen = 1
+		return op, nil
 	case "writesingle":
 		op.FuncCode = WriteSingleRegister // modbus.FuncCodeWriteSingleRegister
+	case "writemultiple":
+		op.FuncCode = WriteMultipleRegisters // modbus.FuncCodeWriteMultipleRegisters
 	default:
 		return rs485.Operation{}, fmt.Errorf("invalid register type: %s", r.Type)
 	}
@@ -295,6 +316,65 @@ func RegisterOperation(r Register) (rs485.Operation, error) {
 	return op, nil
 }
 
+// EncodeRegister encodes a value into register bytes according to the register decoding.
+// Integer decodings round the value and fail if it is out of range.
+func EncodeRegister(decode string, val float64) ([]byte, error) {
+	decode = strings.ToLower(decode)
+
+	var b []byte
+	switch decode {
+	case "float32", "ieee754", "float32s", "ieee754s":
+		b = make([]byte, 4)
+		binary.BigEndian.PutUint32(b, math.Float32bits(float32(val)))
+	case "uint16", "int16", "uint32", "uint32s", "int32", "int32s":
+		u, size, err := encodeInt(decode, math.Round(val))
+		if err != nil {
+			return nil, err
+		}
+
+		b = make([]byte, 8)
+		binary.BigEndian.PutUint64(b, u)
+		b = b[8-size:]
+	default:
+		return nil, fmt.Errorf("invalid register encoding: %s", decode)
+	}
+
+	// swapped word order
+	if strings.HasSuffix(decode, "s") {
+		b = append(b[2:], b[:2]...)
+	}
+
+	return b, nil
+}
+
+// encodeInt range-checks the value and returns its two's complement and byte size
+func encodeInt(decode string, val float64) (uint64, int, error) {
+	var min, max float64
+	var size int
+
+	switch decode {
+	case "uint16":
+		max, size = math.MaxUint16, 2
+	case "int16":
+		min, max, size = math.MinInt16, math.MaxInt16, 2
+	case "uint32", "uint32s":
+		max, size = math.MaxUint32, 4
+	default:
+		min, max, size = math.MinInt32, math.MaxInt32, 4
+	}
+
+	if val < min || val > max {
+		return 0, 0, fmt.Errorf("value out of range for %s: %.0f", decode, val)
+	}
+
+	return uint64(int64(val)), size, nil
+}
+
+// coilToFloat64 converts a single coil reading to 0 or 1
+func coilToFloat64(b []byte) float64 {
+	return float64(b[0] & 1)
+}
+
 // SunSpecOperation is a sunspec modbus operation
 type SunSpecOperation struct {
 	Model, Block int
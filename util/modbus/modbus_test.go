@@ -1,6 +1,10 @@
 package modbus
 
-import "testing"
+import (
+	"bytes"
+	"math"
+	"testing"
+)
 
 func TestParsePoint(t *testing.T) {
 	tc := []struct {
@@ -28,3 +32,70 @@ func TestParsePoint(t *testing.T) {
 		}
 	}
 }
+
+func TestEncodeRegister(t *testing.T) {
+	tc := []struct {
+		decode string
+		val    float64
+		res    []byte
+		err    bool
+	}{
+		{"uint16", 16, []byte{0x00, 0x10}, false},
+		{"uint16", 65536, nil, true},
+		{"uint16", -1, nil, true},
+		{"int16", -2, []byte{0xFF, 0xFE}, false},
+		{"int16", 32768, nil, true},
+		{"uint32", 0x10002, []byte{0x00, 0x01, 0x00, 0x02}, false},
+		{"uint32s", 0x10002, []byte{0x00, 0x02, 0x00, 0x01}, false},
+		{"int32", -2, []byte{0xFF, 0xFF, 0xFF, 0xFE}, false},
+		{"int32s", -2, []byte{0xFF, 0xFE, 0xFF, 0xFF}, false},
+		{"float32", 1, []byte{0x3F, 0x80, 0x00, 0x00}, false},
+		{"float32s", 1, []byte{0x00, 0x00, 0x3F, 0x80}, false},
+		{"int16", 5.6, []byte{0x00, 0x06}, false},
+		{"float64", 1, nil, true},
+	}
+
+	for _, tc := range tc {
+		t.Log(tc)
+
+		res, err := EncodeRegister(tc.decode, tc.val)
+
+		if (err != nil) != tc.err {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		if !bytes.Equal(res, tc.res) {
+			t.Errorf("unexpected result: % x", res)
+		}
+
+		// round trip
+		if !tc.err && tc.val == math.Trunc(tc.val) {
+			op, err := RegisterOperation(Register{Type: "holding", Decode: tc.decode})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if f := op.Transform(res); f != tc.val {
+				t.Errorf("unexpected round trip: %v", f)
+			}
+		}
+	}
+}
+
+func TestRegisterOperationWrite(t *testing.T) {
+	// decoding only applies to reading
+	op, err := RegisterOperation(Register{Type: "writesingle", Decode: "int32"})
+	if err != nil || op.FuncCode != WriteSingleRegister {
+		t.Errorf("unexpected operation: %v %v", op, err)
+	}
+
+	op, err = RegisterOperation(Register{Type: "writemultiple", Decode: "float32"})
+	if err != nil || op.FuncCode != WriteMultipleRegisters {
+		t.Errorf("unexpected operation: %v %v", op, err)
+	}
+
+	op, err = RegisterOperation(Register{Type: "writecoil"})
+	if err != nil || op.FuncCode != WriteSingleCoil {
+		t.Errorf("unexpected operation: %v %v", op, err)
+	}
+}
@@ -0,0 +1,218 @@
+package oauth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/evcc-io/evcc/util"
+	"golang.org/x/oauth2"
+)
+
+// Store is an encrypted file-based token store
+type Store struct {
+	mu     sync.Mutex
+	file   string
+	aead   cipher.AEAD
+	tokens map[string]*oauth2.Token
+}
+
+var store *Store
+
+// ConfigureStore sets up the token store used by all vehicles.
+// The encryption key is derived from secret which is required.
+func ConfigureStore(file, secret string) error {
+	if secret == "" {
+		return errors.New("token store: missing secret")
+	}
+
+	key := sha256.Sum256([]byte(secret))
+
+	s, err := NewStore(file, key[:])
+	if err != nil {
+		return fmt.Errorf("token store: %w", err)
+	}
+
+	store = s
+
+	return nil
+}
+
+// NewStore creates an encrypted token store using a 32 byte key
+func NewStore(file string, key []byte) (*Store, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		file:   file,
+		aead:   aead,
+		tokens: make(map[string]*oauth2.Token),
+	}
+
+	if err := s.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// load decrypts the store file
+func (s *Store) load() error {
+	b, err := ioutil.ReadFile(s.file)
+	if err != nil {
+		return err
+	}
+
+	size := s.aead.NonceSize()
+	if len(b) < size {
+		return errors.New("invalid store file")
+	}
+
+	plain, err := s.aead.Open(nil, b[:size], b[size:], nil)
+	if err != nil {
+		return fmt.Errorf("cannot decrypt %s: %w", s.file, err)
+	}
+
+	return json.Unmarshal(plain, &s.tokens)
+}
+
+// save encrypts the store file
+func (s *Store) save() error {
+	plain, err := json.Marshal(s.tokens)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	return writeFile(s.file, s.aead.Seal(nonce, nonce, plain, nil))
+}
+
+// Get returns the token stored under key
+func (s *Store) Get(key string) (*oauth2.Token, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.tokens[key]
+	if ok {
+		// return a copy
+		t := *token
+		token = &t
+	}
+
+	return token, ok
+}
+
+// Set saves the token under key
+func (s *Store) Set(key string, token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := *token
+	s.tokens[key] = &t
+
+	return s.save()
+}
+
+// writeFile atomically writes a file only accessible by the owner
+func writeFile(file string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+
+	tmp := file + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, file)
+}
+
+// Usable checks if token can be used without login, i.e. it can be refreshed or is not expired
+func Usable(token *oauth2.Token) bool {
+	return token != nil && (token.RefreshToken != "" || token.Valid())
+}
+
+// Load returns the token stored under key regardless of its validity
+func Load(key string) (*oauth2.Token, bool) {
+	if store == nil {
+		return nil, false
+	}
+
+	return store.Get(key)
+}
+
+// Stored returns the token stored under key if it is usable without login.
+// It returns nil if no token is available or the token store is not configured.
+func Stored(key string) *oauth2.Token {
+	if token, ok := Load(key); ok && Usable(token) {
+		return token
+	}
+
+	return nil
+}
+
+// LoadOrLogin returns the stored token for key or obtains a new token using login
+func LoadOrLogin(key string, login func() (*oauth2.Token, error)) (*oauth2.Token, error) {
+	if token := Stored(key); token != nil {
+		return token, nil
+	}
+
+	return login()
+}
+
+// Persist saves token under key if the token store is configured
+func Persist(key string, token *oauth2.Token) {
+	if store == nil || token == nil {
+		return
+	}
+
+	if err := store.Set(key, token); err != nil {
+		util.NewLogger("oauth").ERROR.Printf("token store: %v", err)
+	}
+}
+
+type persistentTokenSource struct {
+	mu    sync.Mutex
+	key   string
+	ts    oauth2.TokenSource
+	token string
+}
+
+// PersistentTokenSource saves new tokens obtained from the token source under key
+func PersistentTokenSource(key string, ts oauth2.TokenSource) oauth2.TokenSource {
+	return &persistentTokenSource{key: key, ts: ts}
+}
+
+func (ps *persistentTokenSource) Token() (*oauth2.Token, error) {
+	token, err := ps.ts.Token()
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if err == nil && token.AccessToken != ps.token {
+		Persist(ps.key, token)
+		ps.token = token.AccessToken
+	}
+
+	return token, err
+}
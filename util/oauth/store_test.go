@@ -0,0 +1,108 @@
+package oauth
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestStore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "tokens")
+
+	if err := ConfigureStore(file, ""); err == nil {
+		t.Error("expected missing secret error")
+	}
+
+	if err := ConfigureStore(file, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { store = nil }()
+
+	expired := &oauth2.Token{AccessToken: "expired", Expiry: time.Now().Add(-time.Hour)}
+	refreshable := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh", Expiry: expired.Expiry}
+
+	Persist("expired", expired)
+	Persist("refreshable", refreshable)
+
+	// reopen using the same secret
+	if err := ConfigureStore(file, "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	if token := Stored("expired"); token != nil {
+		t.Errorf("unexpected token %v", token)
+	}
+
+	if token := Stored("refreshable"); token == nil || token.RefreshToken != "refresh" {
+		t.Errorf("unexpected token %v", token)
+	}
+
+	// wrong secret
+	if err := ConfigureStore(file, "other"); err == nil {
+		t.Error("expected decryption error")
+	}
+}
+
+type tokenSource struct {
+	token *oauth2.Token
+	err   error
+}
+
+func (ts *tokenSource) Token() (*oauth2.Token, error) {
+	return ts.token, ts.err
+}
+
+func TestPersistentTokenSource(t *testing.T) {
+	var err error
+	if store, err = NewStore(filepath.Join(t.TempDir(), "tokens"), make([]byte, 32)); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { store = nil }()
+
+	var login int
+	loginFunc := func() (*oauth2.Token, error) {
+		login++
+		return &oauth2.Token{AccessToken: "login", Expiry: time.Now().Add(time.Hour)}, nil
+	}
+
+	token, err := LoadOrLogin("key", loginFunc)
+	if err != nil || login != 1 {
+		t.Fatal(token, err)
+	}
+
+	ts := &tokenSource{token: token}
+	ps := PersistentTokenSource("key", ts)
+
+	if _, err := ps.Token(); err != nil {
+		t.Fatal(err)
+	}
+
+	// stored token re-used
+	if token, err := LoadOrLogin("key", loginFunc); err != nil || login != 1 || token.AccessToken != "login" {
+		t.Errorf("unexpected login: %v %v", token, err)
+	}
+
+	// refreshed token persisted
+	ts.token = &oauth2.Token{AccessToken: "refreshed", Expiry: time.Now().Add(time.Hour)}
+	if _, err := ps.Token(); err != nil {
+		t.Fatal(err)
+	}
+
+	if token := Stored("key"); token == nil || token.AccessToken != "refreshed" {
+		t.Errorf("unexpected token %v", token)
+	}
+
+	// errors are not persisted
+	ts.err = errors.New("failed")
+	ts.token = &oauth2.Token{}
+	if _, err := ps.Token(); err == nil {
+		t.Error("expected error")
+	}
+
+	if token := Stored("key"); token == nil || token.AccessToken != "refreshed" {
+		t.Errorf("unexpected token %v", token)
+	}
+}
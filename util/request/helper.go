@@ -18,10 +18,21 @@ type Helper struct {
 
 // NewHelper creates http helper for simplified PUT GET logic
 func NewHelper(log *util.Logger) *Helper {
+	return NewLimitedHelper(log, nil)
+}
+
+// NewLimitedHelper creates http helper whose requests are scheduled by the limiter.
+// A nil limiter does not limit requests.
+func NewLimitedHelper(log *util.Logger, limiter *Limiter) *Helper {
+	var transport http.RoundTripper = http.DefaultTransport
+	if limiter != nil {
+		transport = limiter.Transport(transport)
+	}
+
 	r := &Helper{
 		Client: &http.Client{
 			Timeout:   Timeout,
-			Transport: NewTripper(log, http.DefaultTransport),
+			Transport: NewTripper(log, transport),
 		},
 	}
 
@@ -0,0 +1,185 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+// ErrCircuitOpen indicates that requests are suspended after errors
+var ErrCircuitOpen = errors.New("circuit open")
+
+// Circuit breaker states
+const (
+	CircuitClosed   = "closed"    // requests pass
+	CircuitOpen     = "open"      // requests are rejected until backoff has expired
+	CircuitHalfOpen = "half-open" // a single request is probing if the api has recovered
+)
+
+// LimiterConfig is the request limiter configuration
+type LimiterConfig struct {
+	Rate       float64       // maximum requests per minute, 0 for unlimited
+	Backoff    time.Duration // initial backoff after errors
+	MaxBackoff time.Duration // maximum backoff
+}
+
+// DefaultLimiterConfig backs off on errors without limiting the request rate
+var DefaultLimiterConfig = LimiterConfig{
+	Backoff:    30 * time.Second,
+	MaxBackoff: 30 * time.Minute,
+}
+
+// Limiter limits the request rate of an api account shared by multiple clients.
+// After errors or HTTP 429 further requests are rejected with exponential backoff.
+type Limiter struct {
+	mu       sync.Mutex
+	clock    clock.Clock
+	conf     LimiterConfig
+	next     time.Time // earliest time for the next request
+	failures int       // consecutive failures
+	retry    time.Time // requests are rejected until retry
+	probing  bool      // half-open probe request in flight
+}
+
+// NewLimiter creates a request limiter
+func NewLimiter(conf LimiterConfig) *Limiter {
+	if conf.Backoff == 0 {
+		conf.Backoff = DefaultLimiterConfig.Backoff
+	}
+	if conf.MaxBackoff < conf.Backoff {
+		conf.MaxBackoff = DefaultLimiterConfig.MaxBackoff
+	}
+
+	return &Limiter{
+		clock: clock.New(),
+		conf:  conf,
+	}
+}
+
+// State returns the circuit breaker state
+func (l *Limiter) State() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch {
+	case l.failures == 0:
+		return CircuitClosed
+	case l.clock.Now().Before(l.retry):
+		return CircuitOpen
+	default:
+		return CircuitHalfOpen
+	}
+}
+
+// acquire checks the circuit and returns the delay required by the request rate
+func (l *Limiter) acquire() (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+
+	if l.failures > 0 {
+		if now.Before(l.retry) {
+			return 0, fmt.Errorf("%w: retry in %v", ErrCircuitOpen, l.retry.Sub(now).Round(time.Second))
+		}
+
+		if l.probing {
+			return 0, fmt.Errorf("%w: waiting for recovery", ErrCircuitOpen)
+		}
+
+		l.probing = true
+	}
+
+	var delay time.Duration
+	if l.conf.Rate > 0 {
+		if l.next.After(now) {
+			delay = l.next.Sub(now)
+		} else {
+			l.next = now
+		}
+
+		l.next = l.next.Add(time.Duration(float64(time.Minute) / l.conf.Rate))
+	}
+
+	return delay, nil
+}
+
+// release updates the circuit with the request result
+func (l *Limiter) release(resp *http.Response, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.probing = false
+
+	if err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		l.failures = 0
+		return
+	}
+
+	l.failures++
+
+	// exponential backoff with jitter between half and full duration
+	backoff := float64(l.conf.Backoff) * math.Pow(2, float64(l.failures-1))
+	backoff = math.Min(backoff, float64(l.conf.MaxBackoff))
+	backoff = backoff/2 + rand.Float64()*backoff/2
+
+	retry := l.clock.Now().Add(time.Duration(backoff))
+
+	// honour server retry delay
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		if sec, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			if r := l.clock.Now().Add(time.Duration(sec) * time.Second); r.After(retry) {
+				retry = r
+			}
+		}
+	}
+
+	l.retry = retry
+}
+
+// cancel releases the circuit without result
+func (l *Limiter) cancel() {
+	l.mu.Lock()
+	l.probing = false
+	l.mu.Unlock()
+}
+
+// Transport wraps the base transport with the limiter
+func (l *Limiter) Transport(base http.RoundTripper) http.RoundTripper {
+	return &limitedTransport{limiter: l, base: base}
+}
+
+type limitedTransport struct {
+	limiter *Limiter
+	base    http.RoundTripper
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay, err := t.limiter.acquire()
+	if err != nil {
+		return nil, err
+	}
+
+	if delay > 0 {
+		timer := t.limiter.clock.Timer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			t.limiter.cancel()
+			return nil, req.Context().Err()
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	t.limiter.release(resp, err)
+
+	return resp, err
+}
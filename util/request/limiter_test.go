@@ -0,0 +1,114 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+func TestLimiterCircuit(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	l := NewLimiter(LimiterConfig{Backoff: time.Minute, MaxBackoff: 10 * time.Minute})
+	clck := clock.NewMock()
+	l.clock = clck
+
+	client := &http.Client{Transport: l.Transport(http.DefaultTransport)}
+
+	get := func() error {
+		resp, err := client.Get(srv.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := get(); err != nil || l.State() != CircuitClosed {
+		t.Fatal(err, l.State())
+	}
+
+	// server error opens circuit
+	status = http.StatusInternalServerError
+	if err := get(); err != nil || l.State() != CircuitOpen {
+		t.Fatal(err, l.State())
+	}
+
+	if err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected open circuit, got %v", err)
+	}
+
+	// backoff with jitter between 30s and 1m
+	clck.Add(time.Minute)
+	if l.State() != CircuitHalfOpen {
+		t.Errorf("expected half-open circuit, got %s", l.State())
+	}
+
+	// failing probe doubles backoff
+	if err := get(); err != nil || l.State() != CircuitOpen {
+		t.Fatal(err, l.State())
+	}
+
+	if d := l.retry.Sub(clck.Now()); d < time.Minute || d > 2*time.Minute {
+		t.Errorf("unexpected backoff %v", d)
+	}
+
+	clck.Add(2 * time.Minute)
+	if l.State() != CircuitHalfOpen {
+		t.Errorf("expected half-open circuit, got %s", l.State())
+	}
+
+	// 429 honours retry-after
+	status = http.StatusTooManyRequests
+	if err := get(); err != nil {
+		t.Fatal(err)
+	}
+
+	if d := l.retry.Sub(clck.Now()); d != time.Hour {
+		t.Errorf("expected retry after 1h, got %v", d)
+	}
+
+	// successful probe closes circuit
+	clck.Add(time.Hour)
+	status = http.StatusOK
+	if err := get(); err != nil || l.State() != CircuitClosed {
+		t.Fatal(err, l.State())
+	}
+}
+
+func TestLimiterRate(t *testing.T) {
+	l := NewLimiter(LimiterConfig{Rate: 2})
+	clck := clock.NewMock()
+	l.clock = clck
+
+	for i, exp := range []time.Duration{0, 30 * time.Second, time.Minute} {
+		if delay, err := l.acquire(); delay != exp || err != nil {
+			t.Errorf("%d: expected delay %v, got %v %v", i, exp, delay, err)
+		}
+	}
+
+	clck.Add(2 * time.Minute)
+	if delay, _ := l.acquire(); delay != 0 {
+		t.Errorf("unexpected delay %v", delay)
+	}
+}
+
+func TestLimitedHelper(t *testing.T) {
+	l := NewLimiter(DefaultLimiterConfig)
+
+	if tr, ok := NewLimitedHelper(nil, l).Client.Transport.(*roundTripper); !ok || tr.base.(*limitedTransport).limiter != l {
+		t.Error("expected limited transport")
+	}
+
+	if tr := NewHelper(nil).Client.Transport.(*roundTripper); tr.base != http.DefaultTransport {
+		t.Error("unexpected limited transport")
+	}
+}
@@ -0,0 +1,339 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Schema validates documents against the subset of JSON schema draft-07 used by evcc:
+// type, properties, additionalProperties, required, items, enum, pattern and local $ref.
+// Formats are treated as annotations, schemas using other keywords are rejected.
+// Property names are matched case-insensitive like the configuration decoder does.
+type Schema struct {
+	root map[string]interface{}
+}
+
+// Error is a validation error at a document path
+type Error struct {
+	Path string
+	Msg  string
+}
+
+func (e Error) Error() string {
+	if e.Path == "" {
+		return e.Msg
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Msg)
+}
+
+// New parses a JSON schema
+func New(b []byte) (*Schema, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(b, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	if err := check(root, "#"); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	return &Schema{root: root}, nil
+}
+
+// annotations are keywords without effect on validation
+var annotations = map[string]bool{
+	"$schema": true, "$comment": true, "title": true, "description": true,
+	"default": true, "examples": true, "format": true,
+}
+
+// check rejects schemas using keywords that are not supported by the validator
+func check(node map[string]interface{}, path string) error {
+	for key, val := range node {
+		p := path + "/" + key
+
+		switch key {
+		case "$ref", "pattern":
+			if _, ok := val.(string); !ok {
+				return fmt.Errorf("%s: expected string", p)
+			}
+
+		case "type":
+			typ, ok := val.(string)
+			if !ok {
+				return fmt.Errorf("%s: unsupported type %v", p, val)
+			}
+			switch typ {
+			case "null", "boolean", "integer", "number", "string", "array", "object":
+			default:
+				return fmt.Errorf("%s: unsupported type %s", p, typ)
+			}
+
+		case "enum", "required":
+			if _, ok := val.([]interface{}); !ok {
+				return fmt.Errorf("%s: expected array", p)
+			}
+
+		case "items":
+			m, ok := val.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s: expected schema", p)
+			}
+			if err := check(m, p); err != nil {
+				return err
+			}
+
+		case "additionalProperties":
+			if _, ok := val.(bool); ok {
+				continue
+			}
+			m, ok := val.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s: expected boolean or schema", p)
+			}
+			if err := check(m, p); err != nil {
+				return err
+			}
+
+		case "properties", "definitions":
+			props, ok := val.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s: expected object", p)
+			}
+			for name, prop := range props {
+				m, ok := prop.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("%s/%s: expected schema", p, name)
+				}
+				if err := check(m, p+"/"+name); err != nil {
+					return err
+				}
+			}
+
+		default:
+			if !annotations[key] {
+				return fmt.Errorf("%s: unsupported keyword", p)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate returns all validation errors of the document
+func (s *Schema) Validate(doc interface{}) []Error {
+	var res []Error
+	s.validate(s.root, "", normalize(doc), &res)
+	return res
+}
+
+// resolve follows local references like #/definitions/duration
+func (s *Schema) resolve(node map[string]interface{}) (map[string]interface{}, error) {
+	for {
+		ref, ok := node["$ref"].(string)
+		if !ok {
+			return node, nil
+		}
+
+		if !strings.HasPrefix(ref, "#/") {
+			return nil, fmt.Errorf("unsupported reference: %s", ref)
+		}
+
+		var cur interface{} = s.root
+		for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			m, ok := cur.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid reference: %s", ref)
+			}
+			cur = m[segment]
+		}
+
+		next, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid reference: %s", ref)
+		}
+
+		// keywords next to $ref like description are ignored
+		node = next
+	}
+}
+
+func (s *Schema) validate(node map[string]interface{}, path string, val interface{}, res *[]Error) {
+	fail := func(format string, a ...interface{}) {
+		*res = append(*res, Error{Path: path, Msg: fmt.Sprintf(format, a...)})
+	}
+
+	node, err := s.resolve(node)
+	if err != nil {
+		fail("%v", err)
+		return
+	}
+
+	if typ, ok := node["type"].(string); ok && !hasType(val, typ) {
+		fail("expected %s, got %s", typ, typeOf(val))
+		return
+	}
+
+	if enum, ok := node["enum"].([]interface{}); ok {
+		var found bool
+		for _, e := range enum {
+			if reflect.DeepEqual(e, val) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			fail("invalid value %v, must be one of %v", val, enum)
+		}
+	}
+
+	if str, ok := val.(string); ok {
+		if pattern, ok := node["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err != nil {
+				fail("invalid pattern: %v", err)
+			} else if !re.MatchString(str) {
+				fail("invalid value %s, must match %s", str, pattern)
+			}
+		}
+	}
+
+	switch val := val.(type) {
+	case map[string]interface{}:
+		s.validateObject(node, path, val, res)
+
+	case []interface{}:
+		if items, ok := node["items"].(map[string]interface{}); ok {
+			for i, v := range val {
+				s.validate(items, fmt.Sprintf("%s[%d]", path, i), v, res)
+			}
+		}
+	}
+}
+
+func (s *Schema) validateObject(node map[string]interface{}, path string, val map[string]interface{}, res *[]Error) {
+	props, _ := node["properties"].(map[string]interface{})
+
+	// case-insensitive property lookup
+	lookup := func(m map[string]interface{}, key string) (interface{}, bool) {
+		for k, v := range m {
+			if strings.EqualFold(k, key) {
+				return v, true
+			}
+		}
+		return nil, false
+	}
+
+	if required, ok := node["required"].([]interface{}); ok {
+		for _, r := range required {
+			if key, ok := r.(string); ok {
+				if _, ok := lookup(val, key); !ok {
+					*res = append(*res, Error{Path: path, Msg: fmt.Sprintf("missing required property %s", key)})
+				}
+			}
+		}
+	}
+
+	// sort keys for stable output
+	keys := make([]string, 0, len(val))
+	for k := range val {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		p := key
+		if path != "" {
+			p = path + "." + key
+		}
+
+		if prop, ok := lookup(props, key); ok {
+			if prop, ok := prop.(map[string]interface{}); ok {
+				s.validate(prop, p, val[key], res)
+			}
+			continue
+		}
+
+		switch additional := node["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				*res = append(*res, Error{Path: p, Msg: "unknown key"})
+			}
+		case map[string]interface{}:
+			s.validate(additional, p, val[key], res)
+		}
+	}
+}
+
+// normalize converts yaml documents to JSON types
+func normalize(val interface{}) interface{} {
+	switch val := val.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			res[k] = normalize(v)
+		}
+		return res
+
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			res[fmt.Sprintf("%v", k)] = normalize(v)
+		}
+		return res
+
+	case []interface{}:
+		res := make([]interface{}, len(val))
+		for i, v := range val {
+			res[i] = normalize(v)
+		}
+		return res
+
+	case int:
+		return float64(val)
+	case int64:
+		return float64(val)
+	case uint64:
+		return float64(val)
+	case float32:
+		return float64(val)
+	}
+
+	return val
+}
+
+func hasType(val interface{}, typ string) bool {
+	switch typ {
+	case "integer":
+		f, ok := val.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := val.(float64)
+		return ok
+	default:
+		return typeOf(val) == typ
+	}
+}
+
+func typeOf(val interface{}) string {
+	switch val.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", val)
+	}
+}
@@ -0,0 +1,92 @@
+package schema
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const testSchema = `{
+  "type": "object",
+  "properties": {
+    "interval": { "$ref": "#/definitions/duration" },
+    "log": { "enum": ["debug", "error"] },
+    "store": {
+      "type": "object",
+      "properties": { "file": { "type": "string" } },
+      "additionalProperties": false
+    },
+    "items": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["name"],
+        "properties": { "name": { "type": "string" }, "minSoC": { "type": "integer" } }
+      }
+    }
+  },
+  "definitions": {
+    "duration": { "type": "string", "pattern": "\\d[msh]$" }
+  }
+}`
+
+func TestValidate(t *testing.T) {
+	s, err := New([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tc := []struct {
+		doc  string
+		errs []string
+	}{
+		{`interval: 10s`, nil},
+		{`interval: 10`, []string{"interval: expected string, got number"}},
+		{`interval: 10x`, []string{`interval: invalid value 10x, must match \d[msh]$`}},
+		{`log: info`, []string{"log: invalid value info, must be one of [debug error]"}},
+		{`store: { file: foo, secret: bar }`, []string{"store.secret: unknown key"}},
+		{`items: [ { name: foo, minsoc: 20 } ]`, nil},
+		{`items: [ { minSoC: 20.5 } ]`, []string{
+			"items[0]: missing required property name",
+			"items[0].minSoC: expected integer, got number",
+		}},
+		{`unknown: true`, nil},
+	}
+
+	for _, tc := range tc {
+		var doc interface{}
+		if err := yaml.Unmarshal([]byte(tc.doc), &doc); err != nil {
+			t.Fatal(err)
+		}
+
+		errs := s.Validate(doc)
+		if len(errs) != len(tc.errs) {
+			t.Errorf("%s: expected %v, got %v", tc.doc, tc.errs, errs)
+			continue
+		}
+
+		for i, err := range errs {
+			if err.Error() != tc.errs[i] {
+				t.Errorf("%s: expected %s, got %s", tc.doc, tc.errs[i], err.Error())
+			}
+		}
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	for _, tc := range []string{
+		`{ "oneOf": [ { "type": "string" } ] }`,
+		`{ "type": ["string", "null"] }`,
+		`{ "properties": { "soc": { "type": "integer", "minimum": 0 } } }`,
+		`{ "items": { "anyOf": [] } }`,
+		`{ "definitions": { "foo": { "const": 1 } } }`,
+	} {
+		if _, err := New([]byte(tc)); err == nil {
+			t.Errorf("%s: expected error", tc)
+		}
+	}
+
+	if _, err := New([]byte(`{ "title": "foo", "type": "string", "format": "uri" }`)); err != nil {
+		t.Error(err)
+	}
+}
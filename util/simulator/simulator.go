@@ -0,0 +1,581 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+)
+
+// DefaultName is the name of the simulation used if devices don't reference a simulation
+const DefaultName = "default"
+
+const (
+	minCurrent = 6.0 // A, minimum current signalled by the evse
+	rampRate   = 2.0 // A/s, vehicle current increase/decrease
+)
+
+// Schedule events
+const (
+	EventConnect    = "connect"
+	EventDisconnect = "disconnect"
+	EventFault      = "fault"
+	EventRecover    = "recover"
+)
+
+// Event is a daily recurring event at the given time of day (HH:MM)
+type Event struct {
+	Time  string
+	Event string
+}
+
+// EVSEConfig configures the simulated charger
+type EVSEConfig struct {
+	Phases    int
+	Voltage   float64
+	Connected bool    // initial plug state if not defined by schedule
+	Schedule  []Event // daily plug and fault events
+}
+
+// VehicleConfig configures the simulated vehicle
+type VehicleConfig struct {
+	Capacity   float64 // kWh
+	SoC        float64 // initial soc
+	Limit      float64 // vehicle-side charge limit
+	Phases     int     // onboard charger phases
+	MaxCurrent float64
+	Efficiency float64 // charge efficiency
+	DrivePower float64 // W consumed from battery while disconnected
+}
+
+// PVConfig configures the simulated pv production
+type PVConfig struct {
+	Peak    float64       // W at noon
+	Sunrise time.Duration // time of day
+	Sunset  time.Duration // time of day
+}
+
+// DefaultEVSEConfig returns the default charger settings
+func DefaultEVSEConfig() EVSEConfig {
+	return EVSEConfig{
+		Phases:    3,
+		Voltage:   230,
+		Connected: true,
+	}
+}
+
+// DefaultVehicleConfig returns the default vehicle settings
+func DefaultVehicleConfig() VehicleConfig {
+	return VehicleConfig{
+		Capacity:   50,
+		SoC:        50,
+		Limit:      100,
+		Phases:     3,
+		MaxCurrent: 16,
+		Efficiency: 0.9,
+	}
+}
+
+// DefaultPVConfig returns the default pv settings
+func DefaultPVConfig() PVConfig {
+	return PVConfig{
+		Sunrise: 6 * time.Hour,
+		Sunset:  20 * time.Hour,
+	}
+}
+
+// DefaultHomePower is the default household consumption
+const DefaultHomePower = 500
+
+type scheduledEvent struct {
+	offset time.Duration // since midnight
+	event  string
+}
+
+// Simulation simulates an IEC 61851 charger, a vehicle and the site's pv production and household consumption.
+// Time is advanced whenever a value is read.
+type Simulation struct {
+	mu      sync.Mutex
+	clock   clock.Clock
+	updated time.Time
+
+	evse      EVSEConfig
+	vehicle   VehicleConfig
+	pv        PVConfig
+	homePower float64
+	schedule  []scheduledEvent
+
+	// evse state
+	connected        bool
+	fault            bool
+	enabled          bool
+	current          float64 // offered current
+	phases           int
+	dischargeCurrent float64
+
+	// vehicle state
+	soc           float64
+	actual        float64 // actual charge current following the offered current
+	chargedEnergy float64 // kWh
+}
+
+var (
+	mu        sync.Mutex
+	instances = make(map[string]*Simulation)
+)
+
+// Instance returns the named simulation, creating it if necessary
+func Instance(name string) *Simulation {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if name == "" {
+		name = DefaultName
+	}
+
+	s, ok := instances[name]
+	if !ok {
+		s = New(clock.New())
+		instances[name] = s
+	}
+
+	return s
+}
+
+// New creates a simulation with default settings
+func New(clock clock.Clock) *Simulation {
+	s := &Simulation{clock: clock}
+
+	_ = s.ConfigureEVSE(DefaultEVSEConfig())
+	_ = s.ConfigureVehicle(DefaultVehicleConfig())
+	_ = s.ConfigurePV(DefaultPVConfig())
+	s.ConfigureHome(DefaultHomePower)
+
+	return s
+}
+
+// ConfigureEVSE applies the charger configuration
+func (s *Simulation) ConfigureEVSE(cc EVSEConfig) error {
+	if cc.Phases != 1 && cc.Phases != 3 {
+		return fmt.Errorf("invalid phases: %d", cc.Phases)
+	}
+
+	if cc.Voltage <= 0 {
+		return fmt.Errorf("invalid voltage: %.0f", cc.Voltage)
+	}
+
+	var schedule []scheduledEvent
+	for _, e := range cc.Schedule {
+		t, err := time.Parse("15:04", e.Time)
+		if err != nil {
+			return fmt.Errorf("schedule: %w", err)
+		}
+
+		event := strings.ToLower(e.Event)
+		switch event {
+		case EventConnect, EventDisconnect, EventFault, EventRecover:
+		default:
+			return fmt.Errorf("schedule: invalid event: %s", e.Event)
+		}
+
+		offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+		schedule = append(schedule, scheduledEvent{offset: offset, event: event})
+	}
+
+	sort.SliceStable(schedule, func(i, j int) bool {
+		return schedule[i].offset < schedule[j].offset
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evse = cc
+	s.phases = cc.Phases
+	s.connected = cc.Connected
+	s.schedule = schedule
+
+	// restore plug state from the past day's schedule
+	now := s.clock.Now()
+	s.applySchedule(now.Add(-24*time.Hour), now)
+
+	return nil
+}
+
+// ConfigureVehicle applies the vehicle configuration
+func (s *Simulation) ConfigureVehicle(cc VehicleConfig) error {
+	if cc.Capacity <= 0 {
+		return errors.New("missing capacity")
+	}
+
+	if cc.Phases != 1 && cc.Phases != 3 {
+		return fmt.Errorf("invalid phases: %d", cc.Phases)
+	}
+
+	if cc.Limit <= 0 || cc.Limit > 100 {
+		cc.Limit = 100
+	}
+
+	if cc.Efficiency <= 0 || cc.Efficiency > 1 {
+		cc.Efficiency = 1
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.vehicle = cc
+	s.soc = math.Max(0, math.Min(cc.SoC, 100))
+
+	return nil
+}
+
+// ConfigurePV applies the pv configuration
+func (s *Simulation) ConfigurePV(cc PVConfig) error {
+	if cc.Sunset <= cc.Sunrise {
+		return errors.New("sunset must be after sunrise")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pv = cc
+
+	return nil
+}
+
+// ConfigureHome sets the household consumption
+func (s *Simulation) ConfigureHome(power float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.homePower = power
+}
+
+// applySchedule applies all scheduled events in the (from, to] interval
+func (s *Simulation) applySchedule(from, to time.Time) {
+	if len(s.schedule) == 0 || !to.After(from) {
+		return
+	}
+
+	y, m, d := from.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, from.Location())
+
+	for ; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for _, e := range s.schedule {
+			if ts := day.Add(e.offset); ts.After(from) && !ts.After(to) {
+				s.apply(e.event)
+			}
+		}
+	}
+}
+
+// apply executes a single schedule event
+func (s *Simulation) apply(event string) {
+	switch event {
+	case EventConnect:
+		s.connected = true
+	case EventDisconnect:
+		s.connected = false
+	case EventFault:
+		s.fault = true
+	case EventRecover:
+		s.fault = false
+	}
+}
+
+// activePhases returns the phases used by the vehicle
+func (s *Simulation) activePhases() int {
+	if s.vehicle.Phases < s.phases {
+		return s.vehicle.Phases
+	}
+	return s.phases
+}
+
+// charging determines if the vehicle is drawing current
+func (s *Simulation) charging() bool {
+	return s.connected && !s.fault && s.enabled &&
+		s.current >= minCurrent && s.soc < s.vehicle.Limit
+}
+
+// discharging determines if the vehicle is feeding power
+func (s *Simulation) discharging() bool {
+	return s.connected && !s.fault && !s.charging() &&
+		s.dischargeCurrent >= minCurrent && s.soc > 0
+}
+
+// advance updates the simulation to the current time
+func (s *Simulation) advance() {
+	now := s.clock.Now()
+	if s.updated.IsZero() {
+		s.updated = now
+		return
+	}
+
+	dt := now.Sub(s.updated)
+	if dt <= 0 {
+		return
+	}
+
+	s.applySchedule(s.updated, now)
+	s.updated = now
+
+	// vehicle current follows the offered current
+
+	var target float64
+	if s.charging() {
+		target = math.Min(s.current, s.vehicle.MaxCurrent)
+	}
+
+	if step := rampRate * dt.Seconds(); math.Abs(target-s.actual) <= step {
+		s.actual = target
+	} else if target > s.actual {
+		s.actual += step
+	} else {
+		s.actual -= step
+	}
+
+	capacity := s.vehicle.Capacity
+
+	// charging
+	energy := s.chargePower() * dt.Hours() / 1e3
+	s.chargedEnergy += energy
+	s.soc += 100 * energy * s.vehicle.Efficiency / capacity
+
+	// discharging
+	s.soc -= 100 * s.dischargePower() * dt.Hours() / 1e3 / s.vehicle.Efficiency / capacity
+
+	// driving
+	if !s.connected {
+		s.soc -= 100 * s.vehicle.DrivePower * dt.Hours() / 1e3 / capacity
+	}
+
+	s.soc = math.Max(0, math.Min(s.soc, 100))
+}
+
+// chargePower returns the current charging power
+func (s *Simulation) chargePower() float64 {
+	return s.actual * float64(s.activePhases()) * s.evse.Voltage
+}
+
+// dischargePower returns the current discharging power
+func (s *Simulation) dischargePower() float64 {
+	if !s.discharging() {
+		return 0
+	}
+
+	return math.Min(s.dischargeCurrent, s.vehicle.MaxCurrent) * float64(s.activePhases()) * s.evse.Voltage
+}
+
+// pvPower returns the pv production following a sine curve between sunrise and sunset
+func (s *Simulation) pvPower() float64 {
+	if s.pv.Peak <= 0 {
+		return 0
+	}
+
+	now := s.clock.Now()
+	y, m, d := now.Date()
+	tod := now.Sub(time.Date(y, m, d, 0, 0, 0, 0, now.Location()))
+
+	if tod <= s.pv.Sunrise || tod >= s.pv.Sunset {
+		return 0
+	}
+
+	x := float64(tod-s.pv.Sunrise) / float64(s.pv.Sunset-s.pv.Sunrise)
+
+	return s.pv.Peak * math.Sin(math.Pi*x)
+}
+
+// Status returns the IEC 61851 charger status
+func (s *Simulation) Status() api.ChargeStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+
+	switch {
+	case !s.connected:
+		return api.StatusA
+	case s.fault:
+		return api.StatusF
+	case s.charging():
+		return api.StatusC
+	default:
+		return api.StatusB
+	}
+}
+
+// FaultDetail returns the reason for the charger fault, empty if not faulted
+func (s *Simulation) FaultDetail() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fault {
+		return "scheduled fault"
+	}
+
+	return ""
+}
+
+// Enabled returns the charger enabled state
+func (s *Simulation) Enabled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enabled
+}
+
+// Enable enables or disables the charger
+func (s *Simulation) Enable(enable bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+
+	if s.fault {
+		return errors.New("charger fault")
+	}
+
+	s.enabled = enable
+
+	return nil
+}
+
+// SetCurrent sets the offered charge current
+func (s *Simulation) SetCurrent(current float64) error {
+	if current < 0 {
+		return fmt.Errorf("invalid current %.5g", current)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+	s.current = current
+
+	return nil
+}
+
+// SetPhases switches the charger phases. Switching requires charging to be stopped.
+func (s *Simulation) SetPhases(phases int) error {
+	if phases != 1 && phases != 3 {
+		return fmt.Errorf("invalid phases: %d", phases)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+
+	if s.charging() {
+		return errors.New("cannot switch phases while charging")
+	}
+
+	s.phases = phases
+
+	return nil
+}
+
+// SetDischargeCurrent sets the discharge current, zero stops discharging
+func (s *Simulation) SetDischargeCurrent(current float64) error {
+	if current < 0 {
+		return fmt.Errorf("invalid current %.5g", current)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+	s.dischargeCurrent = current
+
+	return nil
+}
+
+// ChargePower returns the charging power in W
+func (s *Simulation) ChargePower() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+
+	return s.chargePower()
+}
+
+// DischargePower returns the discharging power in W
+func (s *Simulation) DischargePower() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+
+	return s.dischargePower()
+}
+
+// ChargedEnergy returns the total charged energy in kWh
+func (s *Simulation) ChargedEnergy() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+
+	return s.chargedEnergy
+}
+
+// Currents returns the charging currents per phase, discharging currents are negative
+func (s *Simulation) Currents() (float64, float64, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+
+	current := s.actual
+	if s.discharging() {
+		current = -math.Min(s.dischargeCurrent, s.vehicle.MaxCurrent)
+	}
+
+	if s.activePhases() == 1 {
+		return current, 0, 0
+	}
+
+	return current, current, current
+}
+
+// SoC returns the vehicle soc
+func (s *Simulation) SoC() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+
+	return s.soc
+}
+
+// PVPower returns the pv production in W
+func (s *Simulation) PVPower() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+
+	return s.pvPower()
+}
+
+// GridPower returns the grid power in W, negative values mean export
+func (s *Simulation) GridPower() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advance()
+
+	return s.homePower + s.chargePower() - s.dischargePower() - s.pvPower()
+}
+
+// Voltage returns the nominal voltage
+func (s *Simulation) Voltage() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.evse.Voltage
+}
@@ -0,0 +1,209 @@
+package simulator
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+)
+
+// midnight returns a mock clock set to midnight
+func midnight() *clock.Mock {
+	clck := clock.NewMock()
+	clck.Set(time.Date(2021, 6, 1, 0, 0, 0, 0, time.Local))
+	return clck
+}
+
+func TestStateMachine(t *testing.T) {
+	clck := midnight()
+	s := New(clck)
+
+	if status := s.Status(); status != api.StatusB {
+		t.Errorf("expected B, got %s", status)
+	}
+
+	// below minimum current
+	_ = s.Enable(true)
+	_ = s.SetCurrent(5)
+	if status := s.Status(); status != api.StatusB {
+		t.Errorf("expected B, got %s", status)
+	}
+
+	_ = s.SetCurrent(16)
+	if status := s.Status(); status != api.StatusC {
+		t.Errorf("expected C, got %s", status)
+	}
+
+	// current ramps up
+	clck.Add(time.Second)
+	if p := s.ChargePower(); p != rampRate*3*230 {
+		t.Errorf("unexpected ramp power: %.0fW", p)
+	}
+
+	clck.Add(time.Minute)
+	if p := s.ChargePower(); p != 16*3*230 {
+		t.Errorf("unexpected power: %.0fW", p)
+	}
+
+	if err := s.SetPhases(1); err == nil {
+		t.Error("expected phase switch error while charging")
+	}
+
+	_ = s.Enable(false)
+	if status := s.Status(); status != api.StatusB {
+		t.Errorf("expected B, got %s", status)
+	}
+
+	if err := s.SetPhases(1); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSoCFollowsEnergy(t *testing.T) {
+	clck := midnight()
+	s := New(clck)
+
+	if err := s.ConfigureVehicle(VehicleConfig{
+		Capacity:   10,
+		SoC:        50,
+		Phases:     1,
+		MaxCurrent: 16,
+		Efficiency: 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = s.Enable(true)
+	_ = s.SetCurrent(10)
+	s.Status()
+
+	// ramp to 10A takes 5s
+	clck.Add(5 * time.Second)
+	s.ChargePower()
+
+	// 1h @ 2.3kW
+	clck.Add(time.Hour)
+	if soc := s.SoC(); math.Abs(soc-73) > 0.1 {
+		t.Errorf("expected 73%%, got %.1f%%", soc)
+	}
+
+	if e := s.ChargedEnergy(); math.Abs(e-2.3) > 0.01 {
+		t.Errorf("expected 2.3kWh, got %.2fkWh", e)
+	}
+
+	// vehicle stops charging at limit
+	clck.Add(3 * time.Hour)
+	if soc := s.SoC(); soc != 100 {
+		t.Errorf("expected 100%%, got %.1f%%", soc)
+	}
+
+	if status := s.Status(); status != api.StatusB {
+		t.Errorf("expected B, got %s", status)
+	}
+}
+
+func TestDischarge(t *testing.T) {
+	clck := midnight()
+	s := New(clck)
+
+	if err := s.ConfigureVehicle(VehicleConfig{
+		Capacity:   10,
+		SoC:        50,
+		Phases:     1,
+		MaxCurrent: 16,
+		Efficiency: 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = s.SetDischargeCurrent(10)
+	s.Status()
+
+	if p := s.DischargePower(); p != 2300 {
+		t.Errorf("expected 2300W, got %.0fW", p)
+	}
+
+	clck.Add(time.Hour)
+	if soc := s.SoC(); math.Abs(soc-27) > 0.1 {
+		t.Errorf("expected 27%%, got %.1f%%", soc)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	clck := midnight()
+	clck.Add(12 * time.Hour)
+
+	s := New(clck)
+
+	if err := s.ConfigureEVSE(EVSEConfig{
+		Phases:  3,
+		Voltage: 230,
+		Schedule: []Event{
+			{Time: "07:00", Event: EventDisconnect},
+			{Time: "18:00", Event: EventConnect},
+			{Time: "20:00", Event: EventFault},
+			{Time: "20:30", Event: EventRecover},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// state restored from past schedule
+	if status := s.Status(); status != api.StatusA {
+		t.Errorf("expected A, got %s", status)
+	}
+
+	tc := []struct {
+		d      time.Duration
+		status api.ChargeStatus
+	}{
+		{6 * time.Hour, api.StatusB},                 // 18:00
+		{2 * time.Hour, api.StatusF},                 // 20:00
+		{30 * time.Minute, api.StatusB},              // 20:30
+		{10*time.Hour + 30*time.Minute, api.StatusA}, // 07:00
+	}
+
+	for _, tc := range tc {
+		clck.Add(tc.d)
+		if status := s.Status(); status != tc.status {
+			t.Errorf("%v: expected %s, got %s", clck.Now(), tc.status, status)
+		}
+	}
+
+	if err := s.ConfigureEVSE(EVSEConfig{
+		Phases:   3,
+		Voltage:  230,
+		Schedule: []Event{{Time: "07:00", Event: "foo"}},
+	}); err == nil {
+		t.Error("expected invalid event error")
+	}
+}
+
+func TestPV(t *testing.T) {
+	clck := midnight()
+	s := New(clck)
+	s.ConfigureHome(0)
+
+	if err := s.ConfigurePV(PVConfig{
+		Peak:    10e3,
+		Sunrise: 6 * time.Hour,
+		Sunset:  20 * time.Hour,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if p := s.PVPower(); p != 0 {
+		t.Errorf("expected no pv at night, got %.0fW", p)
+	}
+
+	clck.Add(13 * time.Hour)
+	if p := s.PVPower(); math.Abs(p-10e3) > 1 {
+		t.Errorf("expected peak pv, got %.0fW", p)
+	}
+
+	if p := s.GridPower(); math.Abs(p+10e3) > 1 {
+		t.Errorf("expected grid export, got %.0fW", p)
+	}
+}
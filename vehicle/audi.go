@@ -47,7 +47,12 @@ func NewAudiFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("audi")
-	identity := vw.NewIdentity(log)
+	limiter, err := cc.embed.accountLimiter("audi", cc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := vw.NewIdentity(log, limiter)
 
 	query := url.Values(map[string][]string{
 		"response_type": {"id_token token"},
@@ -58,12 +63,12 @@ func NewAudiFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		"ui_locales":    {"de-DE"},
 	})
 
-	err := identity.LoginVAG("77869e21-e30a-4a92-b016-48ab7d3db1d8", query, cc.User, cc.Password)
+	err = identity.LoginVAG("77869e21-e30a-4a92-b016-48ab7d3db1d8", query, cc.User, cc.Password)
 	if err != nil {
 		return v, fmt.Errorf("login failed: %w", err)
 	}
 
-	api := vw.NewAPI(log, identity, "Audi", "DE")
+	api := vw.NewAPI(log, limiter, identity, "Audi", "DE")
 	api.Client.Timeout = cc.Timeout
 
 	if cc.VIN == "" {
@@ -79,7 +84,7 @@ func NewAudiFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		}
 	}
 
-	// audiApi := audi.NewAPI(log, identity, "Audi", "DE")
+	// audiApi := audi.NewAPI(log, limiter, identity, "Audi", "DE")
 	// v.audiProvider = audi.NewProvider(audiApi, cc.VIN, cc.Cache)
 
 	return v, err
@@ -22,9 +22,9 @@ type API struct {
 }
 
 // NewAPI creates a new api client
-func NewAPI(log *util.Logger, identity oauth2.TokenSource, brand, country string) *API {
+func NewAPI(log *util.Logger, limiter *request.Limiter, identity oauth2.TokenSource, brand, country string) *API {
 	v := &API{
-		Helper:  request.NewHelper(log),
+		Helper:  request.NewLimitedHelper(log, limiter),
 		brand:   brand,
 		country: country,
 		baseURI: DefaultBaseURI,
@@ -39,11 +39,11 @@ type API struct {
 }
 
 // New creates a new BlueLink API
-func NewAPI(log *util.Logger, identity *Identity, cache time.Duration) *API {
+func NewAPI(log *util.Logger, limiter *request.Limiter, identity *Identity, cache time.Duration) *API {
 	v := &API{
 		log:      log,
 		identity: identity,
-		Helper:   request.NewHelper(log),
+		Helper:   request.NewLimitedHelper(log, limiter),
 	}
 
 	// api is unbelievably slow when retrieving status
@@ -40,17 +40,20 @@ type Config struct {
 type Identity struct {
 	*request.Helper
 	log      *util.Logger
+	limiter  *request.Limiter
 	config   Config
 	deviceID string
 	oauth2.TokenSource
+	user, password string
 }
 
 // NewIdentity creates BlueLink Identity
-func NewIdentity(log *util.Logger, config Config) (*Identity, error) {
+func NewIdentity(log *util.Logger, limiter *request.Limiter, config Config) (*Identity, error) {
 	v := &Identity{
-		log:    log,
-		Helper: request.NewHelper(log),
-		config: config,
+		log:     log,
+		limiter: limiter,
+		Helper:  request.NewLimitedHelper(log, limiter),
+		config:  config,
 	}
 
 	// fetch updated stamps
@@ -96,7 +99,7 @@ func (v *Identity) getDeviceID() (string, error) {
 }
 
 func (v *Identity) getCookies() (cookieClient *request.Helper, err error) {
-	cookieClient = request.NewHelper(v.log)
+	cookieClient = request.NewLimitedHelper(v.log, v.limiter)
 	cookieClient.Client.Jar, err = cookiejar.New(&cookiejar.Options{
 		PublicSuffixList: publicsuffix.List,
 	})
@@ -313,17 +316,37 @@ func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
 		err = v.DoJSON(req, &res)
 	}
 
+	// login again if refresh token has become invalid
+	if se, ok := err.(request.StatusError); ok && se.HasStatus(http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden) {
+		return v.login()
+	}
+
 	return (*oauth2.Token)(&res), err
 }
 
-func (v *Identity) Login(user, password string) (err error) {
-	v.deviceID, err = v.getDeviceID()
+// Login uses the stored token if available or performs the login
+func (v *Identity) Login(user, password string) error {
+	v.user = user
+	v.password = password
+
+	var err error
+	if v.deviceID, err = v.getDeviceID(); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+
+	key := "bluelink:" + v.config.CCSPServiceID + ":" + user
 
-	var cookieClient *request.Helper
+	token, err := oauth.LoadOrLogin(key, v.login)
 	if err == nil {
-		cookieClient, err = v.getCookies()
+		v.TokenSource = oauth.PersistentTokenSource(key, oauth.RefreshTokenSource(token, v))
 	}
 
+	return err
+}
+
+func (v *Identity) login() (*oauth2.Token, error) {
+	cookieClient, err := v.getCookies()
+
 	if err == nil {
 		err = v.setLanguage(cookieClient)
 	}
@@ -331,8 +354,8 @@ func (v *Identity) Login(user, password string) (err error) {
 	var code string
 	if err == nil {
 		// try new login first, then fallback
-		if code, err = v.brandLogin(cookieClient, user, password); err != nil {
-			code, err = v.bluelinkLogin(cookieClient, user, password)
+		if code, err = v.brandLogin(cookieClient, v.user, v.password); err != nil {
+			code, err = v.bluelinkLogin(cookieClient, v.user, v.password)
 		}
 
 		if err != nil {
@@ -340,18 +363,16 @@ func (v *Identity) Login(user, password string) (err error) {
 		}
 	}
 
+	var token oauth.Token
 	if err == nil {
-		var token oauth.Token
-		if token, err = v.exchangeCode(code); err == nil {
-			v.TokenSource = oauth.RefreshTokenSource((*oauth2.Token)(&token), v)
-		}
+		token, err = v.exchangeCode(code)
 	}
 
 	if err != nil {
-		err = fmt.Errorf("login failed: %w", err)
+		return nil, fmt.Errorf("login failed: %w", err)
 	}
 
-	return err
+	return (*oauth2.Token)(&token), nil
 }
 
 // Request creates authenticated request
@@ -39,15 +39,19 @@ func NewBMWFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("bmw")
-	identity := bmw.NewIdentity(log)
+	limiter, err := cc.embed.accountLimiter("bmw", cc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := bmw.NewIdentity(log, limiter)
 
 	if err := identity.Login(cc.User, cc.Password); err != nil {
 		return nil, err
 	}
 
-	api := bmw.NewAPI(log, identity)
+	api := bmw.NewAPI(log, limiter, identity)
 
-	var err error
 	if cc.VIN == "" {
 		cc.VIN, err = findVehicle(api.Vehicles())
 		if err == nil {
@@ -20,6 +20,10 @@ type StatusResponse struct {
 		ChargingLevelHv        int
 		RemainingRangeElectric int
 		Mileage                int
+		Position               struct {
+			Lat, Lon float64
+			Status   string // OK
+		}
 		// UpdateTime             time.Time // 2021-08-12T12:00:08+0000
 	}
 }
@@ -39,9 +43,9 @@ type API struct {
 }
 
 // NewAPI creates a new vehicle
-func NewAPI(log *util.Logger, identity oauth2.TokenSource) *API {
+func NewAPI(log *util.Logger, limiter *request.Limiter, identity oauth2.TokenSource) *API {
 	v := &API{
-		Helper: request.NewHelper(log),
+		Helper: request.NewLimitedHelper(log, limiter),
 	}
 
 	// replace client transport with authenticated transport
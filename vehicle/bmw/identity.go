@@ -24,10 +24,10 @@ type Identity struct {
 }
 
 // NewIdentity creates BMW identity
-func NewIdentity(log *util.Logger) *Identity {
+func NewIdentity(log *util.Logger, limiter *request.Limiter) *Identity {
 	v := &Identity{
 		log:    log,
-		Helper: request.NewHelper(log),
+		Helper: request.NewLimitedHelper(log, limiter),
 	}
 
 	return v
@@ -37,10 +37,13 @@ func (v *Identity) Login(user, password string) error {
 	v.user = user
 	v.password = password
 
-	token, err := v.RefreshToken(nil)
+	key := "bmw:" + user
+	token, err := oauth.LoadOrLogin(key, func() (*oauth2.Token, error) {
+		return v.RefreshToken(nil)
+	})
 
 	if err == nil {
-		v.TokenSource = oauth.RefreshTokenSource(token, v)
+		v.TokenSource = oauth.PersistentTokenSource(key, oauth.RefreshTokenSource(token, v))
 	}
 
 	return err
@@ -89,3 +89,20 @@ func (v *Provider) Odometer() (float64, error) {
 
 	return 0, err
 }
+
+var _ api.VehiclePosition = (*Provider)(nil)
+
+// Position implements the api.VehiclePosition interface
+func (v *Provider) Position() (float64, float64, error) {
+	res, err := v.statusG()
+	if res, ok := res.(StatusResponse); err == nil && ok {
+		pos := res.VehicleStatus.Position
+		if pos.Status != "OK" {
+			return 0, 0, api.ErrNotAvailable
+		}
+
+		return pos.Lat, pos.Lon, nil
+	}
+
+	return 0, 0, err
+}
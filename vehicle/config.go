@@ -1,11 +1,14 @@
 package vehicle
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/vehicle/wrapper"
 )
 
@@ -42,6 +45,49 @@ func Types() []string {
 	return res
 }
 
+// accountLimit is the request limiter of an account and its configuration
+type accountLimit struct {
+	limiter *request.Limiter
+	conf    request.LimiterConfig
+}
+
+var (
+	limitersMu sync.Mutex
+	limiters   = make(map[string]accountLimit)
+)
+
+// sharedLimiter returns the request limiter of the account identified by type and user.
+// Vehicles of the same account share the limiter and must not configure conflicting settings.
+// Vehicles without settings use the account's settings or the default configuration.
+func sharedLimiter(typ, user string, settings *request.LimiterConfig) (*request.Limiter, error) {
+	conf := request.DefaultLimiterConfig
+	if settings != nil {
+		conf = *settings
+	}
+
+	// vehicles without account don't share their limiter
+	if user == "" {
+		return request.NewLimiter(conf), nil
+	}
+
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+
+	key := strings.ToLower(typ + ":" + user)
+
+	limit, ok := limiters[key]
+	if !ok {
+		limit = accountLimit{limiter: request.NewLimiter(conf), conf: conf}
+		limiters[key] = limit
+	}
+
+	if settings != nil && limit.conf != conf {
+		return nil, errors.New("conflicting ratelimit for vehicles of the same account")
+	}
+
+	return limit.limiter, nil
+}
+
 // NewFromConfig creates vehicle from configuration
 func NewFromConfig(typ string, other map[string]interface{}) (v api.Vehicle, err error) {
 	factory, err := registry.Get(strings.ToLower(typ))
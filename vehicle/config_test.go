@@ -3,6 +3,7 @@ package vehicle
 import (
 	"testing"
 
+	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/test"
 )
 
@@ -38,3 +39,44 @@ func TestVehicles(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountLimiter(t *testing.T) {
+	v1, v2, v3 := new(embed), new(embed), new(embed)
+
+	limiter := func(v *embed, typ, user string) *request.Limiter {
+		t.Helper()
+		l, err := v.accountLimiter(typ, user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return l
+	}
+
+	if limiter(v1, "vw", "foo") != limiter(v2, "vw", "Foo") {
+		t.Error("expected shared account limiter")
+	}
+
+	if limiter(v1, "vw", "foo") == limiter(v3, "id", "foo") {
+		t.Error("unexpected shared limiter of different vehicle type")
+	}
+
+	if limiter(v1, "tesla", "") == limiter(v2, "tesla", "") {
+		t.Error("unexpected shared limiter without account")
+	}
+
+	if v1.CircuitState() != request.CircuitClosed {
+		t.Errorf("unexpected circuit state: %s", v1.CircuitState())
+	}
+
+	// conflicting settings of the same account
+	v3.RateLimit_ = &request.LimiterConfig{Rate: 10}
+	if _, err := v3.accountLimiter("vw", "foo"); err == nil {
+		t.Error("expected conflicting ratelimit error")
+	}
+
+	// settings of the account's first vehicle apply to vehicles without settings
+	v1.RateLimit_, v2.RateLimit_ = &request.LimiterConfig{Rate: 10}, nil
+	if limiter(v1, "vw", "bar") != limiter(v2, "vw", "bar") || limiter(v3, "vw", "bar") == nil {
+		t.Error("expected shared account limiter")
+	}
+}
@@ -47,9 +47,13 @@ func NewEnyaqFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 
 	var err error
 	log := util.NewLogger("enyaq")
+	limiter, err := cc.embed.accountLimiter("enyaq", cc.User)
+	if err != nil {
+		return nil, err
+	}
 
 	if cc.VIN == "" {
-		identity := vw.NewIdentity(log)
+		identity := vw.NewIdentity(log, limiter)
 
 		// Skoda native api
 		query := url.Values(map[string][]string{
@@ -64,7 +68,7 @@ func NewEnyaqFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 			return v, fmt.Errorf("login failed: %w", err)
 		}
 
-		api := skoda.NewAPI(log, identity)
+		api := skoda.NewAPI(log, limiter, identity)
 		api.Client.Timeout = cc.Timeout
 
 		cc.VIN, err = findVehicle(api.Vehicles())
@@ -74,7 +78,7 @@ func NewEnyaqFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	if err == nil {
-		identity := vw.NewIdentity(log)
+		identity := vw.NewIdentity(log, limiter)
 
 		// Skoda connect api
 		query := url.Values(map[string][]string{
@@ -89,7 +93,7 @@ func NewEnyaqFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 			return v, fmt.Errorf("login failed: %w", err)
 		}
 
-		api := skoda.NewAPI(log, identity)
+		api := skoda.NewAPI(log, limiter, identity)
 		api.Client.Timeout = cc.Timeout
 
 		v.Provider = skoda.NewProvider(api, strings.ToUpper(cc.VIN), cc.Cache)
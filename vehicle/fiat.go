@@ -48,14 +48,19 @@ func NewFiatFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("fiat")
-	identity := fiat.NewIdentity(log, cc.User, cc.Password)
+	limiter, err := cc.embed.accountLimiter("fiat", cc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := fiat.NewIdentity(log, limiter, cc.User, cc.Password)
 
-	err := identity.Login()
+	err = identity.Login()
 	if err != nil {
 		return nil, fmt.Errorf("login failed: %w", err)
 	}
 
-	api := fiat.NewAPI(log, identity)
+	api := fiat.NewAPI(log, limiter, identity)
 
 	if cc.VIN == "" {
 		cc.VIN, err = findVehicle(api.Vehicles())
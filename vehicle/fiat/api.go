@@ -26,10 +26,10 @@ type API struct {
 	*request.Helper
 }
 
-func NewAPI(log *util.Logger, identity *Identity) *API {
+func NewAPI(log *util.Logger, limiter *request.Limiter, identity *Identity) *API {
 	api := &API{
 		identity: identity,
-		Helper:   request.NewHelper(log),
+		Helper:   request.NewLimitedHelper(log, limiter),
 	}
 
 	return api
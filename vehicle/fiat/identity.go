@@ -33,9 +33,9 @@ type Identity struct {
 }
 
 // NewIdentity creates Fiat identity
-func NewIdentity(log *util.Logger, user, password string) *Identity {
+func NewIdentity(log *util.Logger, limiter *request.Limiter, user, password string) *Identity {
 	return &Identity{
-		Helper:   request.NewHelper(log),
+		Helper:   request.NewLimitedHelper(log, limiter),
 		user:     user,
 		password: password,
 	}
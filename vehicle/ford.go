@@ -60,10 +60,14 @@ func NewFordFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("ford")
+	limiter, err := cc.embed.accountLimiter("ford", cc.User)
+	if err != nil {
+		return nil, err
+	}
 
 	v := &Ford{
 		embed:    &cc.embed,
-		Helper:   request.NewHelper(log),
+		Helper:   request.NewLimitedHelper(log, limiter),
 		log:      log,
 		user:     cc.User,
 		password: cc.Password,
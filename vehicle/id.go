@@ -46,7 +46,12 @@ func NewIDFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("id")
-	identity := vw.NewIdentity(log)
+	limiter, err := cc.embed.accountLimiter("id", cc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := vw.NewIdentity(log, limiter)
 
 	query := url.Values(map[string][]string{
 		"response_type": {"code id_token token"},
@@ -55,12 +60,12 @@ func NewIDFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		"scope":         {"openid profile badge cars dealers vin"},
 	})
 
-	err := identity.LoginID(query, cc.User, cc.Password)
+	err = identity.LoginID(query, cc.User, cc.Password)
 	if err != nil {
 		return v, fmt.Errorf("login failed: %w", err)
 	}
 
-	api := id.NewAPI(log, identity)
+	api := id.NewAPI(log, limiter, identity)
 	api.Client.Timeout = cc.Timeout
 
 	if cc.VIN == "" {
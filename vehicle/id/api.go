@@ -34,9 +34,9 @@ const (
 )
 
 // NewAPI creates a new vehicle
-func NewAPI(log *util.Logger, identity oauth2.TokenSource) *API {
+func NewAPI(log *util.Logger, limiter *request.Limiter, identity oauth2.TokenSource) *API {
 	v := &API{
-		Helper: request.NewHelper(log),
+		Helper: request.NewLimitedHelper(log, limiter),
 	}
 
 	v.Client.Transport = &oauth2.Transport{
@@ -85,6 +85,19 @@ func (v *API) Status(vin string) (res Status, err error) {
 	return res, err
 }
 
+// ParkingPosition implements the /parkingposition response. No position is returned while the vehicle is moving.
+func (v *API) ParkingPosition(vin string) (res ParkingPosition, err error) {
+	uri := fmt.Sprintf("%s/vehicles/%s/parkingposition", BaseURL, vin)
+
+	req, err := request.New(http.MethodGet, uri, nil, request.AcceptJSON)
+
+	if err == nil {
+		err = v.DoJSON(req, &res)
+	}
+
+	return res, err
+}
+
 // Action implements vehicle actions
 func (v *API) Action(vin, action, value string) error {
 	uri := fmt.Sprintf("%s/vehicles/%s/%s/%s", BaseURL, vin, action, value)
@@ -99,6 +112,20 @@ func (v *API) Action(vin, action, value string) error {
 	return err
 }
 
+// Settings implements vehicle settings updates, e.g. the charging target soc
+func (v *API) Settings(vin, action string, settings interface{}) error {
+	uri := fmt.Sprintf("%s/vehicles/%s/%s/%s", BaseURL, vin, action, ActionChargeSettings)
+
+	req, err := request.New(http.MethodPut, uri, request.MarshalJSON(settings), request.JSONEncoding)
+
+	if err == nil {
+		var res interface{}
+		err = v.DoJSON(req, &res)
+	}
+
+	return err
+}
+
 // Any implements any api response
 func (v *API) Any(uri, vin string) (interface{}, error) {
 	if strings.Contains(uri, "%s") {
@@ -1,6 +1,8 @@
 package id
 
 import (
+	"errors"
+	"io"
 	"strings"
 	"time"
 
@@ -10,8 +12,10 @@ import (
 
 // Provider is an api.Vehicle implementation for VW ID cars
 type Provider struct {
-	statusG func() (interface{}, error)
-	action  func(action, value string) error
+	statusG   func() (interface{}, error)
+	positionG func() (interface{}, error)
+	action    func(action, value string) error
+	settings  func(action string, settings interface{}) error
 }
 
 // NewProvider creates a new vehicle
@@ -20,9 +24,15 @@ func NewProvider(api *API, vin string, cache time.Duration) *Provider {
 		statusG: provider.NewCached(func() (interface{}, error) {
 			return api.Status(vin)
 		}, cache).InterfaceGetter(),
+		positionG: provider.NewCached(func() (interface{}, error) {
+			return api.ParkingPosition(vin)
+		}, cache).InterfaceGetter(),
 		action: func(action, value string) error {
 			return api.Action(vin, action, value)
 		},
+		settings: func(action string, settings interface{}) error {
+			return api.Settings(vin, action, settings)
+		},
 	}
 	return impl
 }
@@ -123,6 +133,35 @@ func (v *Provider) Climater() (active bool, outsideTemp float64, targetTemp floa
 	return active, outsideTemp, targetTemp, err
 }
 
+var _ api.VehiclePosition = (*Provider)(nil)
+
+// Position implements the api.VehiclePosition interface
+func (v *Provider) Position() (float64, float64, error) {
+	res, err := v.positionG()
+	if res, ok := res.(ParkingPosition); err == nil && ok {
+		return res.Data.Lat, res.Data.Lon, nil
+	}
+
+	// vehicle is moving
+	if errors.Is(err, io.EOF) {
+		err = api.ErrNotAvailable
+	}
+
+	return 0, 0, err
+}
+
+var _ api.VehicleClimateController = (*Provider)(nil)
+
+// StartClimate implements the api.VehicleClimateController interface
+func (v *Provider) StartClimate() error {
+	return v.action(ActionClimatisation, ActionClimatisationStart)
+}
+
+// StopClimate implements the api.VehicleClimateController interface
+func (v *Provider) StopClimate() error {
+	return v.action(ActionClimatisation, ActionClimatisationStop)
+}
+
 var _ api.VehicleStartCharge = (*Provider)(nil)
 
 // StartCharge implements the api.VehicleStartCharge interface
@@ -136,3 +175,26 @@ var _ api.VehicleStopCharge = (*Provider)(nil)
 func (v *Provider) StopCharge() error {
 	return v.action(ActionCharge, ActionChargeStop)
 }
+
+var _ api.VehicleChargeLimiter = (*Provider)(nil)
+
+// TargetSoC implements the api.VehicleChargeLimiter interface
+func (v *Provider) TargetSoC() (int, error) {
+	res, err := v.statusG()
+	if res, ok := res.(Status); err == nil && ok {
+		return res.Data.ChargingSettings.TargetSOCPercent, nil
+	}
+
+	return 0, err
+}
+
+// SetTargetSoC implements the api.VehicleChargeLimiter interface
+func (v *Provider) SetTargetSoC(soc int) error {
+	data := struct {
+		TargetSOCPercent int `json:"targetSOC_pct"`
+	}{
+		TargetSOCPercent: soc,
+	}
+
+	return v.settings(ActionCharge, data)
+}
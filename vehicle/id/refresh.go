@@ -14,9 +14,9 @@ type tokenRefresher struct {
 	login func() (Token, error)
 }
 
-func Refresher(log *util.Logger, login func() (Token, error)) oauth.TokenRefresher {
+func Refresher(log *util.Logger, limiter *request.Limiter, login func() (Token, error)) oauth.TokenRefresher {
 	return &tokenRefresher{
-		Helper: request.NewHelper(log),
+		Helper: request.NewLimitedHelper(log, limiter),
 		login:  login,
 	}
 }
@@ -101,6 +101,15 @@ type MaintenanceStatus struct {
 	OilServiceDueKm      int       `json:"oilServiceDue_km"`
 }
 
+// ParkingPosition is the /parkingposition api
+type ParkingPosition struct {
+	Data struct {
+		CarCapturedTimestamp Timestamp
+		Lat                  float64
+		Lon                  float64
+	}
+}
+
 // Timestamp implements JSON unmarshal for RFC3339 string timestamp
 type Timestamp struct {
 	time.Time
@@ -49,7 +49,12 @@ func NewKiaFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("kia")
-	identity, err := bluelink.NewIdentity(log, settings)
+	limiter, err := cc.embed.accountLimiter("kia", cc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := bluelink.NewIdentity(log, limiter, settings)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +63,7 @@ func NewKiaFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		return nil, err
 	}
 
-	api := bluelink.NewAPI(log, identity, cc.Cache)
+	api := bluelink.NewAPI(log, limiter, identity, cc.Cache)
 
 	vehicles, err := api.Vehicles()
 	if err != nil {
@@ -47,15 +47,19 @@ func NewNissanFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("nissan")
-	identity := nissan.NewIdentity(log)
+	limiter, err := cc.embed.accountLimiter("nissan", cc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := nissan.NewIdentity(log, limiter)
 
 	if err := identity.Login(cc.User, cc.Password); err != nil {
 		return v, fmt.Errorf("login failed: %w", err)
 	}
 
-	api := nissan.NewAPI(log, identity, strings.ToUpper(cc.VIN))
+	api := nissan.NewAPI(log, limiter, identity, strings.ToUpper(cc.VIN))
 
-	var err error
 	if cc.VIN == "" {
 		api.VIN, err = findVehicle(api.Vehicles())
 		if err == nil {
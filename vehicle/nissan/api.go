@@ -24,9 +24,9 @@ type API struct {
 	refreshTime time.Time
 }
 
-func NewAPI(log *util.Logger, identity oauth2.TokenSource, vin string) *API {
+func NewAPI(log *util.Logger, limiter *request.Limiter, identity oauth2.TokenSource, vin string) *API {
 	v := &API{
-		Helper: request.NewHelper(log),
+		Helper: request.NewLimitedHelper(log, limiter),
 		VIN:    vin,
 	}
 
@@ -19,9 +19,9 @@ type Identity struct {
 }
 
 // NewIdentity creates Nissan identity
-func NewIdentity(log *util.Logger) *Identity {
+func NewIdentity(log *util.Logger, limiter *request.Limiter) *Identity {
 	return &Identity{
-		Helper: request.NewHelper(log),
+		Helper: request.NewLimitedHelper(log, limiter),
 	}
 }
 
@@ -49,10 +49,14 @@ func NewNiuFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("niu")
+	limiter, err := cc.embed.accountLimiter("niu", cc.User)
+	if err != nil {
+		return nil, err
+	}
 
 	v := &Niu{
 		embed:    &cc.embed,
-		Helper:   request.NewHelper(log),
+		Helper:   request.NewLimitedHelper(log, limiter),
 		user:     cc.User,
 		password: cc.Password,
 		serial:   strings.ToUpper(cc.Serial),
@@ -27,6 +27,11 @@ type ovmsChargeResponse struct {
 	Soc              string `json:"soc"`
 }
 
+type ovmsLocationResponse struct {
+	Latitude  string `json:"latitude"`
+	Longitude string `json:"longitude"`
+}
+
 type ovmsConnectResponse struct {
 	NetConnected int `json:"v_net_connected"`
 }
@@ -38,6 +43,7 @@ type Ovms struct {
 	user, password, vehicleId, server string
 	chargeG                           func() (interface{}, error)
 	statusG                           func() (interface{}, error)
+	locationG                         func() (interface{}, error)
 }
 
 func init() {
@@ -59,10 +65,14 @@ func NewOvmsFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("ovms")
+	limiter, err := cc.embed.accountLimiter("ovms", cc.User)
+	if err != nil {
+		return nil, err
+	}
 
 	v := &Ovms{
 		embed:     &cc.embed,
-		Helper:    request.NewHelper(log),
+		Helper:    request.NewLimitedHelper(log, limiter),
 		user:      cc.User,
 		password:  cc.Password,
 		vehicleId: cc.VehicleID,
@@ -71,8 +81,8 @@ func NewOvmsFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 
 	v.chargeG = provider.NewCached(v.batteryAPI, cc.Cache).InterfaceGetter()
 	v.statusG = provider.NewCached(v.statusAPI, cc.Cache).InterfaceGetter()
+	v.locationG = provider.NewCached(v.locationAPI, cc.Cache).InterfaceGetter()
 
-	var err error
 	v.Jar, err = cookiejar.New(&cookiejar.Options{
 		PublicSuffixList: publicsuffix.List,
 	})
@@ -124,6 +134,13 @@ func (v *Ovms) statusRequest() (ovmsStatusResponse, error) {
 	return res, err
 }
 
+func (v *Ovms) locationRequest() (ovmsLocationResponse, error) {
+	uri := fmt.Sprintf("http://%s:6868/api/location/%s", v.server, v.vehicleId)
+	var res ovmsLocationResponse
+	err := v.GetJSON(uri, &res)
+	return res, err
+}
+
 // batteryAPI provides battery-status api response
 func (v *Ovms) batteryAPI() (interface{}, error) {
 	var resp ovmsChargeResponse
@@ -159,6 +176,21 @@ func (v *Ovms) statusAPI() (interface{}, error) {
 	return resp, err
 }
 
+// locationAPI provides vehicle location api response
+func (v *Ovms) locationAPI() (interface{}, error) {
+	var resp ovmsLocationResponse
+
+	resp, err := v.locationRequest()
+	if err != nil {
+		err = v.authFlow()
+		if err == nil {
+			resp, err = v.locationRequest()
+		}
+	}
+
+	return resp, err
+}
+
 // SoC implements the api.Vehicle interface
 func (v *Ovms) SoC() (float64, error) {
 	res, err := v.chargeG()
@@ -218,6 +250,25 @@ func (v *Ovms) Odometer() (float64, error) {
 	return 0, err
 }
 
+var _ api.VehiclePosition = (*Ovms)(nil)
+
+// Position implements the api.VehiclePosition interface
+func (v *Ovms) Position() (float64, float64, error) {
+	res, err := v.locationG()
+
+	if res, ok := res.(ovmsLocationResponse); err == nil && ok {
+		lat, err := strconv.ParseFloat(res.Latitude, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		lon, err := strconv.ParseFloat(res.Longitude, 64)
+		return lat, lon, err
+	}
+
+	return 0, 0, err
+}
+
 var _ api.VehicleFinishTimer = (*Ovms)(nil)
 
 // FinishTime implements the api.VehicleFinishTimer interface
@@ -40,7 +40,12 @@ func NewPorscheFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		return nil, err
 	}
 
-	identity := porsche.NewIdentity(log, cc.User, cc.Password)
+	limiter, err := cc.embed.accountLimiter("porsche", cc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := porsche.NewIdentity(log, limiter, cc.User, cc.Password)
 
 	accessTokens, err := identity.Login()
 	if err != nil {
@@ -54,9 +59,9 @@ func NewPorscheFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 
 	var provider porscheProvider
 	if vehicle.EmobilityVehicle {
-		provider = porsche.NewEMobilityProvider(log, identity, accessTokens.EmobilityToken, vehicle.VIN, cc.Cache)
+		provider = porsche.NewEMobilityProvider(log, limiter, identity, accessTokens.EmobilityToken, vehicle.VIN, cc.Cache)
 	} else {
-		provider = porsche.NewProvider(log, identity, accessTokens.Token, vehicle.VIN, cc.Cache)
+		provider = porsche.NewProvider(log, limiter, identity, accessTokens.Token, vehicle.VIN, cc.Cache)
 	}
 
 	v := &Porsche{
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
 	cv "github.com/nirasan/go-oauth-pkce-code-verifier"
 	"golang.org/x/net/publicsuffix"
@@ -40,10 +41,10 @@ type Identity struct {
 }
 
 // NewIdentity creates Porsche identity
-func NewIdentity(log *util.Logger, user, password string) *Identity {
+func NewIdentity(log *util.Logger, limiter *request.Limiter, user, password string) *Identity {
 	v := &Identity{
 		log:      log,
-		Helper:   request.NewHelper(log),
+		Helper:   request.NewLimitedHelper(log, limiter),
 		user:     user,
 		password: password,
 	}
@@ -61,7 +62,52 @@ func NewIdentity(log *util.Logger, user, password string) *Identity {
 	return v
 }
 
+// Login returns the stored access tokens if still valid or performs a login
 func (v *Identity) Login() (AccessTokens, error) {
+	if accessTokens, ok := v.stored(); ok {
+		return accessTokens, nil
+	}
+
+	accessTokens, err := v.login()
+	if err == nil {
+		oauth.Persist(v.key(false), &accessTokens.Token)
+		oauth.Persist(v.key(true), &accessTokens.EmobilityToken)
+	}
+
+	return accessTokens, err
+}
+
+// key returns the token store key
+func (v *Identity) key(emobility bool) string {
+	if emobility {
+		return "porsche-emobility:" + v.user
+	}
+	return "porsche:" + v.user
+}
+
+// stored returns the stored access tokens if valid. The emobility token may be empty
+// if the account doesn't support the emobility api.
+func (v *Identity) stored() (AccessTokens, bool) {
+	var accessTokens AccessTokens
+
+	token := oauth.Stored(v.key(false))
+	if token == nil {
+		return accessTokens, false
+	}
+
+	// emobility token is stored empty if not supported
+	emobility, ok := oauth.Load(v.key(true))
+	if !ok || emobility.AccessToken != "" && !emobility.Valid() {
+		return accessTokens, false
+	}
+
+	accessTokens.Token = *token
+	accessTokens.EmobilityToken = *emobility
+
+	return accessTokens, true
+}
+
+func (v *Identity) login() (AccessTokens, error) {
 	var accessTokens AccessTokens
 
 	// get the login page to get the cookies for the subsequent requests
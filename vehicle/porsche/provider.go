@@ -43,10 +43,10 @@ type Provider struct {
 }
 
 // NewProvider creates a new vehicle
-func NewProvider(log *util.Logger, identity *Identity, token oauth2.Token, vin string, cache time.Duration) *Provider {
+func NewProvider(log *util.Logger, limiter *request.Limiter, identity *Identity, token oauth2.Token, vin string, cache time.Duration) *Provider {
 	impl := &Provider{
 		log:      log,
-		Helper:   request.NewHelper(log),
+		Helper:   request.NewLimitedHelper(log, limiter),
 		token:    token,
 		identity: identity,
 	}
@@ -77,11 +77,11 @@ type EMobilityProvider struct {
 }
 
 // NewEMobilityProvider creates a new vehicle
-func NewEMobilityProvider(log *util.Logger, identity *Identity, token oauth2.Token, vin string, cache time.Duration) *EMobilityProvider {
+func NewEMobilityProvider(log *util.Logger, limiter *request.Limiter, identity *Identity, token oauth2.Token, vin string, cache time.Duration) *EMobilityProvider {
 	impl := &EMobilityProvider{
 		log:      log,
 		token:    token,
-		Helper:   request.NewHelper(log),
+		Helper:   request.NewLimitedHelper(log, limiter),
 		identity: identity,
 	}
 
@@ -85,13 +85,18 @@ func newPSA(log *util.Logger, brand, realm, id, secret string, other map[string]
 		embed: &cc.embed,
 	}
 
-	identity := psa.NewIdentity(log, brand, cc.Credentials.ID, cc.Credentials.Secret)
+	limiter, err := cc.embed.accountLimiter(brand, cc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := psa.NewIdentity(log, limiter, brand, cc.Credentials.ID, cc.Credentials.Secret)
 
 	if err := identity.Login(cc.User, cc.Password); err != nil {
 		return v, fmt.Errorf("login failed: %w", err)
 	}
 
-	api := psa.NewAPI(log, identity, realm, cc.Credentials.ID)
+	api := psa.NewAPI(log, limiter, identity, realm, cc.Credentials.ID)
 
 	vehicles, err := api.Vehicles()
 	if err != nil {
@@ -24,9 +24,9 @@ type API struct {
 }
 
 // NewAPI creates a new vehicle
-func NewAPI(log *util.Logger, identity oauth2.TokenSource, realm, id string) *API {
+func NewAPI(log *util.Logger, limiter *request.Limiter, identity oauth2.TokenSource, realm, id string) *API {
 	v := &API{
-		Helper: request.NewHelper(log),
+		Helper: request.NewLimitedHelper(log, limiter),
 		realm:  realm,
 		id:     id,
 	}
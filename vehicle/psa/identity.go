@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
 	"golang.org/x/oauth2"
 )
@@ -16,9 +17,9 @@ type Identity struct {
 }
 
 // NewIdentity creates PSA identity
-func NewIdentity(log *util.Logger, brand, id, secret string) *Identity {
+func NewIdentity(log *util.Logger, limiter *request.Limiter, brand, id, secret string) *Identity {
 	return &Identity{
-		Helper: request.NewHelper(log),
+		Helper: request.NewLimitedHelper(log, limiter),
 		oc: &oauth2.Config{
 			ClientID:     id,
 			ClientSecret: secret,
@@ -39,10 +40,20 @@ func (v *Identity) Login(user, password string) error {
 		v.Client,
 	)
 
+	// use stored token if it can still be refreshed
+	key := "psa:" + v.oc.ClientID + ":" + user
+	if token := oauth.Stored(key); token != nil {
+		ts := v.oc.TokenSource(ctx, token)
+		if _, err := ts.Token(); err == nil {
+			v.TokenSource = oauth.PersistentTokenSource(key, ts)
+			return nil
+		}
+	}
+
 	// replace client with authenticated oauth client
 	token, err := v.oc.PasswordCredentialsToken(ctx, user, password)
 	if err == nil {
-		v.TokenSource = v.oc.TokenSource(ctx, token)
+		v.TokenSource = oauth.PersistentTokenSource(key, v.oc.TokenSource(ctx, token))
 	}
 
 	return err
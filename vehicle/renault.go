@@ -129,16 +129,20 @@ func NewRenaultFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("renault")
+	limiter, err := cc.embed.accountLimiter("renault", cc.User)
+	if err != nil {
+		return nil, err
+	}
 
 	v := &Renault{
 		embed:    &cc.embed,
-		Helper:   request.NewHelper(log),
+		Helper:   request.NewLimitedHelper(log, limiter),
 		user:     cc.User,
 		password: cc.Password,
 		vin:      strings.ToUpper(cc.VIN),
 	}
 
-	err := v.apiKeys(cc.Region)
+	err = v.apiKeys(cc.Region)
 	if err == nil {
 		err = v.authFlow()
 	}
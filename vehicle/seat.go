@@ -46,7 +46,12 @@ func NewSeatFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("seat")
-	identity := vw.NewIdentity(log)
+	limiter, err := cc.embed.accountLimiter("seat", cc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := vw.NewIdentity(log, limiter)
 
 	query := url.Values(map[string][]string{
 		"response_type": {"code id_token"},
@@ -55,12 +60,12 @@ func NewSeatFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		"scope":         {"openid profile mbb cars birthdate nickname address phone"},
 	})
 
-	err := identity.LoginVAG("9dcc70f0-8e79-423a-a3fa-4065d99088b4", query, cc.User, cc.Password)
+	err = identity.LoginVAG("9dcc70f0-8e79-423a-a3fa-4065d99088b4", query, cc.User, cc.Password)
 	if err != nil {
 		return v, fmt.Errorf("login failed: %w", err)
 	}
 
-	api := vw.NewAPI(log, identity, "VW", "ES")
+	api := vw.NewAPI(log, limiter, identity, "VW", "ES")
 	api.Client.Timeout = cc.Timeout
 
 	if cc.VIN == "" {
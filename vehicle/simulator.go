@@ -0,0 +1,83 @@
+package vehicle
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/simulator"
+)
+
+// Simulator is a simulated vehicle whose soc follows the energy charged by the simulated charger
+type Simulator struct {
+	*embed
+	sim *simulator.Simulation
+}
+
+func init() {
+	registry.Add("simulator", NewSimulatorFromConfig)
+}
+
+// NewSimulatorFromConfig creates a simulated vehicle from generic config
+func NewSimulatorFromConfig(other map[string]interface{}) (api.Vehicle, error) {
+	vc := simulator.DefaultVehicleConfig()
+
+	cc := struct {
+		embed      `mapstructure:",squash"`
+		Simulation string
+		SoC        float64
+		Limit      float64
+		Phases     int
+		MaxCurrent float64
+		Efficiency float64
+		DrivePower float64
+	}{
+		embed: embed{
+			Title_:    "Simulator",
+			Capacity_: int64(vc.Capacity),
+		},
+		SoC:        vc.SoC,
+		Limit:      vc.Limit,
+		Phases:     vc.Phases,
+		MaxCurrent: vc.MaxCurrent,
+		Efficiency: vc.Efficiency,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	v := &Simulator{
+		embed: &cc.embed,
+		sim:   simulator.Instance(cc.Simulation),
+	}
+
+	err := v.sim.ConfigureVehicle(simulator.VehicleConfig{
+		Capacity:   float64(cc.Capacity_),
+		SoC:        cc.SoC,
+		Limit:      cc.Limit,
+		Phases:     cc.Phases,
+		MaxCurrent: cc.MaxCurrent,
+		Efficiency: cc.Efficiency,
+		DrivePower: cc.DrivePower,
+	})
+
+	return v, err
+}
+
+// SoC implements the api.Vehicle interface
+func (v *Simulator) SoC() (float64, error) {
+	return v.sim.SoC(), nil
+}
+
+var _ api.ChargeState = (*Simulator)(nil)
+
+// Status implements the api.ChargeState interface
+func (v *Simulator) Status() (api.ChargeStatus, error) {
+	status := v.sim.Status()
+
+	// vehicle doesn't know about charger faults
+	if status == api.StatusF {
+		status = api.StatusB
+	}
+
+	return status, nil
+}
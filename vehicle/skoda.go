@@ -46,7 +46,12 @@ func NewSkodaFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("skoda")
-	identity := vw.NewIdentity(log)
+	limiter, err := cc.embed.accountLimiter("skoda", cc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := vw.NewIdentity(log, limiter)
 
 	query := url.Values(map[string][]string{
 		"response_type": {"code id_token"},
@@ -55,12 +60,12 @@ func NewSkodaFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		"scope":         {"openid profile phone address cars email birthdate badge dealers driversLicense mbb"},
 	})
 
-	err := identity.LoginVAG("28cd30c6-dee7-4529-a0e6-b1e07ff90b79", query, cc.User, cc.Password)
+	err = identity.LoginVAG("28cd30c6-dee7-4529-a0e6-b1e07ff90b79", query, cc.User, cc.Password)
 	if err != nil {
 		return v, fmt.Errorf("login failed: %w", err)
 	}
 
-	api := vw.NewAPI(log, identity, "VW", "CZ")
+	api := vw.NewAPI(log, limiter, identity, "VW", "CZ")
 	api.Client.Timeout = cc.Timeout
 
 	if cc.VIN == "" {
@@ -17,9 +17,9 @@ type API struct {
 }
 
 // NewAPI creates a new api client
-func NewAPI(log *util.Logger, identity oauth2.TokenSource) *API {
+func NewAPI(log *util.Logger, limiter *request.Limiter, identity oauth2.TokenSource) *API {
 	v := &API{
-		Helper: request.NewHelper(log),
+		Helper: request.NewLimitedHelper(log, limiter),
 	}
 
 	v.Client.Transport = &oauth2.Transport{
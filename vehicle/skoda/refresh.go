@@ -16,9 +16,9 @@ type tokenRefresher struct {
 	login func() (oauth.Token, error)
 }
 
-func Refresher(log *util.Logger, login func() (oauth.Token, error)) oauth.TokenRefresher {
+func Refresher(log *util.Logger, limiter *request.Limiter, login func() (oauth.Token, error)) oauth.TokenRefresher {
 	return &tokenRefresher{
-		Helper: request.NewHelper(log),
+		Helper: request.NewLimitedHelper(log, limiter),
 		login:  login,
 	}
 }
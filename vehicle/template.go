@@ -0,0 +1,20 @@
+package vehicle
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/templates"
+)
+
+func init() {
+	registry.Add("template", NewTemplateFromConfig)
+}
+
+// NewTemplateFromConfig creates a vehicle from a parameterized device template
+func NewTemplateFromConfig(other map[string]interface{}) (api.Vehicle, error) {
+	typ, conf, err := templates.Instantiate(templates.Vehicle, other)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFromConfig(typ, conf)
+}
@@ -2,7 +2,9 @@ package vehicle
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -20,6 +22,7 @@ type Tesla struct {
 	vehicle       *tesla.Vehicle
 	chargeStateG  func() (interface{}, error)
 	vehicleStateG func() (interface{}, error)
+	driveStateG   func() (interface{}, error)
 }
 
 func init() {
@@ -51,7 +54,16 @@ func NewTeslaFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 
 	// authenticated http client with logging injected to the Tesla client
 	log := util.NewLogger("tesla")
-	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, request.NewHelper(log).Client)
+
+	// the account is identified by its refresh token
+	account := fmt.Sprintf("%x", sha256.Sum256([]byte(cc.Tokens.Refresh)))
+
+	limiter, err := cc.embed.accountLimiter("tesla", account)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, request.NewLimitedHelper(log, limiter).Client)
 
 	options := []tesla.ClientOption{tesla.WithToken(&oauth2.Token{
 		AccessToken:  cc.Tokens.Access,
@@ -85,18 +97,38 @@ func NewTeslaFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 
 	v.chargeStateG = provider.NewCached(v.chargeState, cc.Cache).InterfaceGetter()
 	v.vehicleStateG = provider.NewCached(v.vehicleState, cc.Cache).InterfaceGetter()
+	v.driveStateG = provider.NewCached(v.driveState, cc.Cache).InterfaceGetter()
 
 	return v, nil
 }
 
+// asleep checks if the api error is caused by a sleeping vehicle
+func asleep(err error) bool {
+	return err != nil && err.Error() == "408 Request Timeout"
+}
+
+// state maps sleeping vehicle errors to api.ErrMustRetry
+func state(res interface{}, err error) (interface{}, error) {
+	if asleep(err) {
+		err = api.ErrMustRetry
+	}
+
+	return res, err
+}
+
 // chargeState implements the charge state api
 func (v *Tesla) chargeState() (interface{}, error) {
-	return v.vehicle.ChargeState()
+	return state(v.vehicle.ChargeState())
 }
 
 // vehicleState implements the climater api
 func (v *Tesla) vehicleState() (interface{}, error) {
-	return v.vehicle.VehicleState()
+	return state(v.vehicle.VehicleState())
+}
+
+// driveState implements the position api
+func (v *Tesla) driveState() (interface{}, error) {
+	return state(v.vehicle.DriveState())
 }
 
 // SoC implements the api.Vehicle interface
@@ -172,6 +204,19 @@ func (v *Tesla) Odometer() (float64, error) {
 	return 0, err
 }
 
+var _ api.VehiclePosition = (*Tesla)(nil)
+
+// Position implements the api.VehiclePosition interface
+func (v *Tesla) Position() (float64, float64, error) {
+	res, err := v.driveStateG()
+
+	if res, ok := res.(*tesla.DriveState); err == nil && ok {
+		return res.Latitude, res.Longitude, nil
+	}
+
+	return 0, 0, err
+}
+
 var _ api.VehicleFinishTimer = (*Tesla)(nil)
 
 // FinishTime implements the api.VehicleFinishTimer interface
@@ -188,35 +233,48 @@ func (v *Tesla) FinishTime() (time.Time, error) {
 
 // TODO api.Climater implementation has been removed as it drains battery. Re-check at a later time.
 
+var _ api.VehicleClimateController = (*Tesla)(nil)
+
+// StartClimate implements the api.VehicleClimateController interface
+func (v *Tesla) StartClimate() error {
+	return v.wakeup(v.vehicle.StartAirConditioning)
+}
+
+// StopClimate implements the api.VehicleClimateController interface
+func (v *Tesla) StopClimate() error {
+	err := v.vehicle.StopAirConditioning()
+
+	// ignore sleeping vehicle
+	if asleep(err) {
+		err = nil
+	}
+
+	return err
+}
+
 var _ api.VehicleStartCharge = (*Tesla)(nil)
 
-// StartCharge implements the api.VehicleStartCharge interface
-func (v *Tesla) StartCharge() error {
-	err := v.vehicle.StartCharging()
+// wakeup executes the command and wakes up a sleeping vehicle. The command is not retried,
+// instead api.ErrMustRetry signals the caller to repeat the command once the vehicle is awake.
+func (v *Tesla) wakeup(command func() error) error {
+	err := command()
 
-	if err != nil && err.Error() == "408 Request Timeout" {
+	if asleep(err) {
 		if _, err := v.vehicle.Wakeup(); err != nil {
 			return err
 		}
 
-		timer := time.NewTimer(90 * time.Second)
-
-		for {
-			select {
-			case <-timer.C:
-				return api.ErrTimeout
-			default:
-				time.Sleep(2 * time.Second)
-				if err := v.vehicle.StartCharging(); err == nil || err.Error() != "408 Request Timeout" {
-					return err
-				}
-			}
-		}
+		return api.ErrMustRetry
 	}
 
 	return err
 }
 
+// StartCharge implements the api.VehicleStartCharge interface
+func (v *Tesla) StartCharge() error {
+	return v.wakeup(v.vehicle.StartCharging)
+}
+
 var _ api.VehicleStopCharge = (*Tesla)(nil)
 
 // StopCharge implements the api.VehicleStopCharge interface
@@ -224,9 +282,29 @@ func (v *Tesla) StopCharge() error {
 	err := v.vehicle.StopCharging()
 
 	// ignore sleeping vehicle
-	if err != nil && err.Error() == "408 Request Timeout" {
+	if asleep(err) {
 		err = nil
 	}
 
 	return err
 }
+
+var _ api.VehicleChargeLimiter = (*Tesla)(nil)
+
+// TargetSoC implements the api.VehicleChargeLimiter interface
+func (v *Tesla) TargetSoC() (int, error) {
+	res, err := v.chargeStateG()
+
+	if res, ok := res.(*tesla.ChargeState); err == nil && ok {
+		return res.ChargeLimitSoc, nil
+	}
+
+	return 0, err
+}
+
+// SetTargetSoC implements the api.VehicleChargeLimiter interface
+func (v *Tesla) SetTargetSoC(soc int) error {
+	return v.wakeup(func() error {
+		return v.vehicle.SetChargeLimit(soc)
+	})
+}
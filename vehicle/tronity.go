@@ -75,6 +75,10 @@ func NewTronityFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 
 	// authenticated http client with logging injected to the tronity client
 	log := util.NewLogger("tronity")
+	limiter, err := cc.embed.accountLimiter("tronity", cc.Credentials.ID)
+	if err != nil {
+		return nil, err
+	}
 
 	oc, err := tronity.OAuth2Config(cc.Credentials.ID, cc.Credentials.Secret)
 	if err != nil {
@@ -84,7 +88,7 @@ func NewTronityFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	v := &Tronity{
 		log:    log,
 		embed:  &cc.embed,
-		Helper: request.NewHelper(log),
+		Helper: request.NewLimitedHelper(log, limiter),
 		oc:     oc,
 	}
 
@@ -96,7 +100,7 @@ func NewTronityFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		ts = oauth.RefreshTokenSource(&oauth2.Token{}, v)
 	} else {
 		// use provided tokens generated by code flow
-		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, request.NewHelper(log).Client)
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, request.NewLimitedHelper(log, limiter).Client)
 		ts = oc.TokenSource(ctx, &oauth2.Token{
 			AccessToken:  cc.Tokens.Access,
 			RefreshToken: cc.Tokens.Refresh,
@@ -152,7 +156,7 @@ func (v *Tronity) RefreshToken(_ *oauth2.Token) (*oauth2.Token, error) {
 	}
 
 	var token oauth2.Token
-	err = request.NewHelper(v.log).DoJSON(req, &token)
+	err = request.NewLimitedHelper(v.log, v.limiter).DoJSON(req, &token)
 
 	return &token, err
 }
@@ -7,12 +7,15 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/provider"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
 )
 
 type embed struct {
-	Title_      string `mapstructure:"title"`
-	Capacity_   int64  `mapstructure:"capacity"`
-	Identifier_ string `mapstructure:"identifier"`
+	Title_      string                 `mapstructure:"title"`
+	Capacity_   int64                  `mapstructure:"capacity"`
+	Identifier_ string                 `mapstructure:"identifier"`
+	RateLimit_  *request.LimiterConfig `mapstructure:"ratelimit"`
+	limiter     *request.Limiter
 }
 
 // Title implements the api.Vehicle interface
@@ -30,6 +33,22 @@ func (v *embed) Identify() (string, error) {
 	return v.Identifier_, nil
 }
 
+// accountLimiter returns the request limiter shared by all vehicles of the same account.
+// It must be passed to all request helpers of the vehicle.
+func (v *embed) accountLimiter(typ, user string) (*request.Limiter, error) {
+	limiter, err := sharedLimiter(typ, user, v.RateLimit_)
+	v.limiter = limiter
+	return limiter, err
+}
+
+// CircuitState implements the api.CircuitBreaker interface
+func (v *embed) CircuitState() string {
+	if v.limiter == nil {
+		return ""
+	}
+	return v.limiter.State()
+}
+
 //go:generate go run ../cmd/tools/decorate.go -f decorateVehicle -b api.Vehicle -t "api.ChargeState,Status,func() (api.ChargeStatus, error)" -t "api.VehicleRange,Range,func() (int64, error)" -t "api.VehicleOdometer,Odometer,func() (float64, error)"
 
 // Vehicle is an api.Vehicle implementation with configurable getters and setters.
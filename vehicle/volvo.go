@@ -109,10 +109,14 @@ func NewVolvoFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("volvo")
+	limiter, err := cc.embed.accountLimiter("volvo", cc.User)
+	if err != nil {
+		return nil, err
+	}
 
 	v := &Volvo{
 		embed:    &cc.embed,
-		Helper:   request.NewHelper(log),
+		Helper:   request.NewLimitedHelper(log, limiter),
 		user:     cc.User,
 		password: cc.Password,
 		vin:      cc.VIN,
@@ -120,7 +124,6 @@ func NewVolvoFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 
 	v.statusG = provider.NewCached(v.status, cc.Cache).InterfaceGetter()
 
-	var err error
 	if cc.VIN == "" {
 		v.vin, err = findVehicle(v.vehicles())
 		if err == nil {
@@ -46,7 +46,12 @@ func NewVWFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	}
 
 	log := util.NewLogger("vw")
-	identity := vw.NewIdentity(log)
+	limiter, err := cc.embed.accountLimiter("vw", cc.User)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := vw.NewIdentity(log, limiter)
 
 	query := url.Values(map[string][]string{
 		"response_type": {"id_token token"},
@@ -55,12 +60,12 @@ func NewVWFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		"scope":         {"openid profile mbb cars birthdate nickname address phone"},
 	})
 
-	err := identity.LoginVAG("38761134-34d0-41f3-9a73-c4be88d7d337", query, cc.User, cc.Password)
+	err = identity.LoginVAG("38761134-34d0-41f3-9a73-c4be88d7d337", query, cc.User, cc.Password)
 	if err != nil {
 		return v, fmt.Errorf("login failed: %w", err)
 	}
 
-	api := vw.NewAPI(log, identity, "VW", "DE")
+	api := vw.NewAPI(log, limiter, identity, "VW", "DE")
 	api.Client.Timeout = cc.Timeout
 
 	if cc.VIN == "" {
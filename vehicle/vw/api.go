@@ -25,9 +25,9 @@ type API struct {
 }
 
 // NewAPI creates a new api client
-func NewAPI(log *util.Logger, identity oauth2.TokenSource, brand, country string) *API {
+func NewAPI(log *util.Logger, limiter *request.Limiter, identity oauth2.TokenSource, brand, country string) *API {
 	v := &API{
-		Helper:  request.NewHelper(log),
+		Helper:  request.NewLimitedHelper(log, limiter),
 		brand:   brand,
 		country: country,
 		baseURI: DefaultBaseURI,
@@ -169,21 +169,55 @@ func (v *API) Climater(vin string) (ClimaterResponse, error) {
 	return res, err
 }
 
+// PositionResponse is the /bs/cf/v1/%s/%s/vehicles/%s/position api
+type PositionResponse struct {
+	FindCarResponse struct {
+		Position struct {
+			TimestampCarSent string
+			CarCoordinate    struct {
+				Latitude  int // micro degrees
+				Longitude int // micro degrees
+			}
+		}
+		ParkingTimeUTC string
+	}
+}
+
+// Position implements the /position response. No position is returned while the vehicle is moving.
+func (v *API) Position(vin string) (PositionResponse, error) {
+	var res PositionResponse
+	uri := fmt.Sprintf("%s/bs/cf/v1/%s/%s/vehicles/%s/position", v.baseURI, v.brand, v.country, vin)
+	err := v.getJSON(uri, &res)
+	return res, err
+}
+
 const (
 	ActionCharge      = "batterycharge"
 	ActionChargeStart = "start"
 	ActionChargeStop  = "stop"
+
+	ActionClimatisation      = "climatisation"
+	ActionClimatisationStart = "startClimatisation"
+	ActionClimatisationStop  = "stopClimatisation"
 )
 
 type actionDefinition struct {
 	contentType string
 	appendix    string
+	settings    map[string]string // additional action settings by value
 }
 
 var actionDefinitions = map[string]actionDefinition{
 	ActionCharge: {
-		"application/vnd.vwg.mbb.ChargerAction_v1_0_0+xml",
-		"charger/actions",
+		contentType: "application/vnd.vwg.mbb.ChargerAction_v1_0_0+xml",
+		appendix:    "charger/actions",
+	},
+	ActionClimatisation: {
+		contentType: "application/vnd.vwg.mbb.ClimaterAction_v1_0_0+xml",
+		appendix:    "climater/actions",
+		settings: map[string]string{
+			ActionClimatisationStart: "<settings><heaterSource>electric</heaterSource></settings>",
+		},
 	},
 }
 
@@ -192,7 +226,7 @@ func (v *API) Action(vin, action, value string) error {
 	def := actionDefinitions[action]
 
 	uri := fmt.Sprintf("%s/bs/%s/v1/%s/%s/vehicles/%s/%s", v.baseURI, action, v.brand, v.country, vin, def.appendix)
-	body := "<?xml version=\"1.0\" encoding=\"UTF-8\" ?><action><type>" + value + "</type></action>"
+	body := "<?xml version=\"1.0\" encoding=\"UTF-8\" ?><action><type>" + value + "</type>" + def.settings[value] + "</action>"
 
 	req, err := request.New(http.MethodPost, uri, strings.NewReader(body), map[string]string{
 		"Content-type": def.contentType,
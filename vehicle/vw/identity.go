@@ -36,16 +36,18 @@ const (
 
 // Identity provides the identity.vwgroup.io login token source
 type Identity struct {
-	log *util.Logger
+	log     *util.Logger
+	limiter *request.Limiter
 	*request.Helper
 	oauth2.TokenSource
 }
 
 // NewIdentity creates VW identity
-func NewIdentity(log *util.Logger) *Identity {
+func NewIdentity(log *util.Logger, limiter *request.Limiter) *Identity {
 	v := &Identity{
-		log:    log,
-		Helper: request.NewHelper(log),
+		log:     log,
+		limiter: limiter,
+		Helper:  request.NewLimitedHelper(log, limiter),
 	}
 
 	return v
@@ -194,9 +196,14 @@ func (v *Identity) LoginVAG(clientID string, query url.Values, user, password st
 		return token, err
 	}
 
-	token, err := login()
+	key := "vw:" + clientID + ":" + user
+	token, err := oauth.LoadOrLogin(key, func() (*oauth2.Token, error) {
+		token, err := login()
+		return (*oauth2.Token)(&token), err
+	})
+
 	if err == nil {
-		v.TokenSource = oauth.RefreshTokenSource((*oauth2.Token)(&token), Refresher(v.log, login, clientID))
+		v.TokenSource = oauth.PersistentTokenSource(key, oauth.RefreshTokenSource(token, Refresher(v.log, v.limiter, login, clientID)))
 	}
 
 	return err
@@ -232,9 +239,14 @@ func (v *Identity) LoginSkoda(query url.Values, user, password string) error {
 		return token, err
 	}
 
-	token, err := login()
+	key := "skoda:" + query.Get("client_id") + ":" + user
+	token, err := oauth.LoadOrLogin(key, func() (*oauth2.Token, error) {
+		token, err := login()
+		return (*oauth2.Token)(&token), err
+	})
+
 	if err == nil {
-		v.TokenSource = oauth.RefreshTokenSource((*oauth2.Token)(&token), skoda.Refresher(v.log, login))
+		v.TokenSource = oauth.PersistentTokenSource(key, oauth.RefreshTokenSource(token, skoda.Refresher(v.log, v.limiter, login)))
 	}
 
 	return err
@@ -273,9 +285,14 @@ func (v *Identity) LoginID(query url.Values, user, password string) error {
 		return token, err
 	}
 
-	token, err := login()
+	key := "id:" + user
+	token, err := oauth.LoadOrLogin(key, func() (*oauth2.Token, error) {
+		token, err := login()
+		return (*oauth2.Token)(&token), err
+	})
+
 	if err == nil {
-		v.TokenSource = oauth.RefreshTokenSource((*oauth2.Token)(&token), id.Refresher(v.log, login))
+		v.TokenSource = oauth.PersistentTokenSource(key, oauth.RefreshTokenSource(token, id.Refresher(v.log, v.limiter, login)))
 	}
 
 	return err
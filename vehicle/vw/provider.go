@@ -1,6 +1,8 @@
 package vw
 
 import (
+	"errors"
+	"io"
 	"math"
 	"strings"
 	"time"
@@ -11,9 +13,10 @@ import (
 
 // Provider implements the evcc vehicle api
 type Provider struct {
-	chargerG func() (interface{}, error)
-	climateG func() (interface{}, error)
-	action   func(action, value string) error
+	chargerG  func() (interface{}, error)
+	climateG  func() (interface{}, error)
+	positionG func() (interface{}, error)
+	action    func(action, value string) error
 }
 
 // NewProvider provides the evcc vehicle api provider
@@ -25,6 +28,9 @@ func NewProvider(api *API, vin string, cache time.Duration) *Provider {
 		climateG: provider.NewCached(func() (interface{}, error) {
 			return api.Climater(vin)
 		}, cache).InterfaceGetter(),
+		positionG: provider.NewCached(func() (interface{}, error) {
+			return api.Position(vin)
+		}, cache).InterfaceGetter(),
 		action: func(action, value string) error {
 			return api.Action(vin, action, value)
 		},
@@ -120,6 +126,36 @@ func (v *Provider) Climater() (active bool, outsideTemp float64, targetTemp floa
 	return active, outsideTemp, targetTemp, err
 }
 
+var _ api.VehiclePosition = (*Provider)(nil)
+
+// Position implements the api.VehiclePosition interface
+func (v *Provider) Position() (float64, float64, error) {
+	res, err := v.positionG()
+	if res, ok := res.(PositionResponse); err == nil && ok {
+		coord := res.FindCarResponse.Position.CarCoordinate
+		return float64(coord.Latitude) / 1e6, float64(coord.Longitude) / 1e6, nil
+	}
+
+	// vehicle is moving
+	if errors.Is(err, io.EOF) {
+		err = api.ErrNotAvailable
+	}
+
+	return 0, 0, err
+}
+
+var _ api.VehicleClimateController = (*Provider)(nil)
+
+// StartClimate implements the api.VehicleClimateController interface
+func (v *Provider) StartClimate() error {
+	return v.action(ActionClimatisation, ActionClimatisationStart)
+}
+
+// StopClimate implements the api.VehicleClimateController interface
+func (v *Provider) StopClimate() error {
+	return v.action(ActionClimatisation, ActionClimatisationStop)
+}
+
 var _ api.VehicleStartCharge = (*Provider)(nil)
 
 // StartCharge implements the api.VehicleStartCharge interface
@@ -17,9 +17,9 @@ type tokenRefresher struct {
 	clientID string
 }
 
-func Refresher(log *util.Logger, login func() (oauth.Token, error), clientID string) oauth.TokenRefresher {
+func Refresher(log *util.Logger, limiter *request.Limiter, login func() (oauth.Token, error), clientID string) oauth.TokenRefresher {
 	return &tokenRefresher{
-		Helper:   request.NewHelper(log),
+		Helper:   request.NewLimitedHelper(log, limiter),
 		login:    login,
 		clientID: clientID,
 	}